@@ -0,0 +1,7 @@
+data "device42_building" "example" {
+  name = "DC-01"
+}
+
+output "example" {
+  value = data.device42_building.example
+}
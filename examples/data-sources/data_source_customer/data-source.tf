@@ -0,0 +1,7 @@
+data "device42_customer" "example" {
+  name = "CUST1"
+}
+
+output "example" {
+  value = data.device42_customer.example
+}
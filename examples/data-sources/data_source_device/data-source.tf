@@ -0,0 +1,7 @@
+data "device42_device" "example" {
+  name = "server-01"
+}
+
+output "example" {
+  value = data.device42_device.example
+}
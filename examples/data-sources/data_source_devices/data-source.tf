@@ -0,0 +1,9 @@
+data "device42_devices" "example" {
+  type     = "physical"
+  building = "DC-01"
+  tags     = "prod,web"
+}
+
+output "example" {
+  value = data.device42_devices.example.devices[*].name
+}
@@ -0,0 +1,7 @@
+data "device42_ipam_vlan" "example" {
+  vlan_id = "1"
+}
+
+output "example" {
+  value = data.device42_ipam_vlan.example
+}
@@ -0,0 +1,9 @@
+data "device42_rack" "example" {
+  name     = "A01"
+  building = "DC-01"
+  room     = "Hall A"
+}
+
+output "example" {
+  value = data.device42_rack.example
+}
@@ -0,0 +1,8 @@
+data "device42_room" "example" {
+  name     = "Hall A"
+  building = "DC-01"
+}
+
+output "example" {
+  value = data.device42_room.example
+}
@@ -0,0 +1,6 @@
+resource "device42_building" "example" {
+  name          = "DC-01"
+  address       = "1 Example Street, Springfield"
+  contact_name  = "Facilities"
+  contact_phone = "555-0100"
+}
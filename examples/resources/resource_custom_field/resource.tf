@@ -0,0 +1,20 @@
+resource "device42_custom_field" "cost_centre" {
+  object_type = "subnet"
+  object_id   = device42_ipam_subnet.example.subnet_id
+  key         = "cost_centre"
+  type        = "picklist"
+  picklist    = "CC-1000,CC-2000"
+  value       = "CC-1000"
+}
+
+resource "device42_ipam_subnet" "other" {
+  name      = "OTHER"
+  mask_bits = "29"
+  network   = "10.25.1.0"
+
+  custom_fields = {
+    cost_centre = "CC-2000"
+  }
+
+  depends_on = [device42_custom_field.cost_centre]
+}
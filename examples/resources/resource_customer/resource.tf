@@ -0,0 +1,15 @@
+resource "device42_customer" "example" {
+  name         = "CUST1"
+  contact_info = "noc@cust1.example.com"
+  notes        = "Managed by Terraform"
+
+  custom_fields = {
+    cost_centre = "CC-1234"
+  }
+}
+
+resource "device42_ipam_subnet" "example" {
+  network   = "10.20.0.0"
+  mask_bits = "24"
+  customer  = device42_customer.example.name
+}
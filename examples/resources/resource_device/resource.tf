@@ -0,0 +1,18 @@
+resource "device42_device" "example" {
+  name          = "vm01.example.com"
+  type          = "virtual"
+  virtual_host  = "esx01.example.com"
+  os            = "Ubuntu"
+  customer      = "CUST1"
+  service_level = "Production"
+  tags          = "TERRAFORM,CUST1"
+  notes         = "Managed by Terraform."
+
+  custom_fields = {
+    owner = "team-platform"
+  }
+}
+
+output "example" {
+  value = device42_device.example
+}
@@ -0,0 +1,8 @@
+resource "device42_dns_record" "example" {
+  zone       = device42_dns_zone.example.name
+  nameserver = device42_dns_zone.example.nameserver
+  type       = "CNAME"
+  name       = "www"
+  content    = "web-01.example.com"
+  ttl        = 3600
+}
@@ -0,0 +1,10 @@
+resource "device42_dns_zone" "example" {
+  name       = "example.com"
+  nameserver = "ns1.example.com"
+  tags       = ["prod"]
+}
+
+resource "device42_dns_zone" "reverse" {
+  name       = "0.10.in-addr.arpa"
+  nameserver = "ns1.example.com"
+}
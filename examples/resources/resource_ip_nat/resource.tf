@@ -0,0 +1,9 @@
+resource "device42_ip_nat" "example" {
+  name              = "web-01 https"
+  ip_address_from   = device42_ipam_ip.public.ipaddress
+  ip_address_to     = device42_ipam_ip.web01.ipaddress
+  protocol          = "tcp"
+  source_port_start = 443
+  target_port_start = 8443
+  two_way_relation  = true
+}
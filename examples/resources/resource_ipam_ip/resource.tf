@@ -23,4 +23,15 @@ resource "device42_ipam_ip" "example2" {
 
 output "example2" {
   value = device42_ipam_ip.example2
-}
\ No newline at end of file
+}
+resource "device42_ipam_ip" "example3" {
+  subnet_id = device42_ipam_subnet.example.subnet_id
+  ipaddress = "10.25.0.3"
+
+  dns {
+    name         = "server3"
+    zone         = "example.com"
+    reverse_zone = "0.25.10.in-addr.arpa"
+    nameserver   = "ns1.example.com"
+  }
+}
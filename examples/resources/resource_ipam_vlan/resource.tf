@@ -8,15 +8,18 @@ output "debug" {
   value = device42_ipam_vlan.example
 }
 
-## Will check if VLAN exists based on tags and number and import that into state if so. Else create a new VLAN.
-## Limitation on filtering here is on API side. Unable to filter by name/vlan_id as of 27/8/21.
+## Will check if a VLAN matching the `match` block exists and import that into state if so. Else create a new VLAN.
 
 resource "device42_ipam_vlan" "example2" {
   check_if_exists = true
 
-  name       = "VLAN-CUST2-EXAMPLE"
-  tags_exist = "TEST,TEST2,CUST2"           # used for matching
-  tags       = "TEST,TEST2,CUST2,TERRAFORM" # tags to update/create vlan with
+  name = "VLAN-CUST2-EXAMPLE"
+  tags = "TEST,TEST2,CUST2,TERRAFORM" # tags to update/create vlan with
+
+  match {
+    name     = "VLAN-CUST2-EXAMPLE"
+    tags_and = "TEST,TEST2,CUST2"
+  }
 }
 
 output "debug" {
@@ -24,15 +27,18 @@ output "debug" {
 }
 
 ## Will create a VLAN using the next sequentially available VLAN within provider range.
-## VLANs that match the tags_range will be considered 'used'.
-## Limitation on filtering here is on API side. Unable to filter by name/vlan_id as of 27/8/21.
+## VLANs that match the `match` block will be considered 'used'. The new VLAN is linked to the matched switches unless `switches` is set.
 
 resource "device42_ipam_vlan" "example3" {
   create_within_range = "666-766"
 
-  name       = "VLAN-CUST3-EXAMPLE"
-  tags_range = "L2-WAN-01,DC-01"
-  tags       = "L2-WAN-01,DC-01,CUST3"
+  name = "VLAN-CUST3-EXAMPLE"
+  tags = "L2-WAN-01,DC-01,CUST3"
+
+  match {
+    tags_and = "L2-WAN-01"
+    building = "DC-01"
+  }
 }
 
 output "debug" {
@@ -0,0 +1,17 @@
+resource "device42_device" "example" {
+  name = "vm01.example.com"
+  type = "virtual"
+}
+
+resource "device42_mac_address" "example" {
+  macaddress = "00:50:56:00:00:01"
+  device_id  = device42_device.example.device_id
+  port_name  = "eth0"
+}
+
+resource "device42_ipam_ip" "example" {
+  subnet_id  = "1234"
+  suggest_ip = true
+  device_id  = device42_device.example.device_id
+  macaddress = device42_mac_address.example.macaddress
+}
@@ -0,0 +1,9 @@
+resource "device42_rack" "example" {
+  name        = "A01"
+  room_id     = device42_room.example.room_id
+  size        = 42
+  row         = "A"
+  orientation = "up"
+  start_row   = 1
+  start_col   = 1
+}
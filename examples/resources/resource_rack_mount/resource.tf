@@ -0,0 +1,15 @@
+resource "device42_rack_mount" "example" {
+  device_id   = device42_device.example.device_id
+  rack_id     = device42_rack.example.rack_id
+  start_at    = 20
+  orientation = "front"
+}
+
+## A 0U PDU mounted on the left side of the rack.
+
+resource "device42_rack_mount" "pdu" {
+  device_id = device42_device.pdu.device_id
+  rack_id   = device42_rack.example.rack_id
+  start_at  = 1
+  side      = "left"
+}
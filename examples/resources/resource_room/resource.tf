@@ -0,0 +1,9 @@
+resource "device42_building" "example" {
+  name    = "DC-01"
+  address = "1 Example Street, Springfield"
+}
+
+resource "device42_room" "example" {
+  name        = "Hall A"
+  building_id = device42_building.example.building_id
+}
@@ -0,0 +1,14 @@
+resource "device42_switch_port" "access" {
+  switch_id   = device42_device.sw01.device_id
+  port        = "Gi1/0/1"
+  description = "web-01 eth0"
+  vlan_ids    = device42_ipam_vlan.web.vlan_id
+}
+
+resource "device42_switch_port" "trunk" {
+  switch_id      = device42_device.sw01.device_id
+  port           = "Te1/1/1"
+  description    = "uplink to core-01"
+  vlan_ids       = join(",", [device42_ipam_vlan.web.vlan_id, device42_ipam_vlan.db.vlan_id])
+  remote_port_id = device42_switch_port.core01_uplink.switch_port_id
+}
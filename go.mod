@@ -3,6 +3,8 @@ module github.com/poroping/terraform-provider-device42
 go 1.15
 
 require (
+	github.com/go-openapi/runtime v0.19.19
+	github.com/go-openapi/strfmt v0.19.5
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
 	github.com/poroping/libdevice42 v0.1.1
 	github.com/thoas/go-funk v0.9.0
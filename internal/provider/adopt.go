@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// What to do when the object a resource would create already exists in
+// Device42, either found with `check_if_exists`/`on_existing` or given by ID.
+const (
+	// fail the create
+	onExistingError = "error"
+	// take the object over and update it to the configuration
+	onExistingAdopt = "adopt"
+	// take the object over without ever modifying it
+	onExistingAdoptReadonly = "adopt_readonly"
+)
+
+var onExistingPolicies = []string{onExistingError, onExistingAdopt, onExistingAdoptReadonly}
+
+func onExistingSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description:  "What to do if the " + what + " already exists. One of `error`, `adopt` (manage it from now on) or `adopt_readonly` (track it in state but never modify or delete it).",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(onExistingPolicies, false),
+	}
+}
+
+func adoptedSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description: "The `on_existing` policy the " + what + " was adopted with, empty if it was created by Terraform.",
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+// onExistingPolicy returns the configured `on_existing` policy. Without it
+// `check_if_exists` means adopt, otherwise existing objects aren't looked for.
+func onExistingPolicy(d *schema.ResourceData) string {
+	if v := d.Get("on_existing").(string); v != "" {
+		return v
+	}
+	if v, ok := d.GetOk("check_if_exists"); ok && v.(bool) {
+		return onExistingAdopt
+	}
+	return ""
+}
+
+// adoptExisting takes over the existing object id according to the
+// `on_existing` policy, `adopt` being the default for an ID set in
+// configuration.
+func adoptExisting(ctx context.Context, d *schema.ResourceData, meta interface{}, what, id string, update, read schema.UpdateContextFunc) diag.Diagnostics {
+	policy := onExistingPolicy(d)
+
+	switch policy {
+	case onExistingError:
+		return diag.Errorf("error %s %s already exists.", what, id)
+	case onExistingAdoptReadonly:
+		log.Printf("[INFO] adopting %s %s read-only", what, id)
+		d.SetId(id)
+		d.Set("adopted", policy)
+		return read(ctx, d, meta)
+	default:
+		log.Printf("[INFO] adopting %s %s", what, id)
+		d.SetId(id)
+		d.Set("adopted", onExistingAdopt)
+		return update(ctx, d, meta)
+	}
+}
+
+// adoptedReadonly reports whether the object is adopted read-only. Switching
+// `on_existing` to another policy hands it over to Terraform.
+func adoptedReadonly(d *schema.ResourceData) bool {
+	if d.Get("adopted").(string) != onExistingAdoptReadonly {
+		return false
+	}
+	if d.Get("on_existing").(string) == onExistingAdoptReadonly {
+		return true
+	}
+	d.Set("adopted", onExistingAdopt)
+	return false
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOnExistingPolicy(t *testing.T) {
+	var tests = []struct {
+		resource *schema.Resource
+		raw      map[string]interface{}
+		policy   string
+	}{
+		{resourceIpamSubnet(), map[string]interface{}{}, ""},
+		{resourceIpamSubnet(), map[string]interface{}{"check_if_exists": true}, onExistingAdopt},
+		{resourceIpamSubnet(), map[string]interface{}{"check_if_exists": true, "on_existing": "error"}, onExistingError},
+		{resourceIpamVlan(), map[string]interface{}{"on_existing": "adopt_readonly"}, onExistingAdoptReadonly},
+		{resourceIpamIP(), map[string]interface{}{}, ""},
+		{resourceIpamIP(), map[string]interface{}{"on_existing": "adopt"}, onExistingAdopt},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("Testing on_existing policy, %v", i), func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, tt.resource.Schema, tt.raw)
+			if got := onExistingPolicy(d); got != tt.policy {
+				t.Errorf("got %q, want %q", got, tt.policy)
+			}
+		})
+	}
+}
+
+func TestAdoptedReadonly(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceIpamVlan().Schema, map[string]interface{}{"on_existing": "adopt_readonly"})
+	d.Set("adopted", onExistingAdoptReadonly)
+
+	if !adoptedReadonly(d) {
+		t.Errorf("expected vlan to be adopted read-only")
+	}
+
+	// handing the object over to Terraform
+	d = schema.TestResourceDataRaw(t, resourceIpamVlan().Schema, map[string]interface{}{"on_existing": "adopt"})
+	d.Set("adopted", onExistingAdoptReadonly)
+
+	if adoptedReadonly(d) {
+		t.Errorf("expected vlan to be managed after switching on_existing")
+	}
+	if v := d.Get("adopted").(string); v != onExistingAdopt {
+		t.Errorf("got adopted %q, want %q", v, onExistingAdopt)
+	}
+}
@@ -0,0 +1,486 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/poroping/libdevice42/client"
+	"github.com/poroping/libdevice42/client/devices"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+	"github.com/poroping/libdevice42/models"
+)
+
+// Operations the generated libdevice42 client can't express, mostly because
+// its models drop fields Device42 does return (e.g. custom fields on vlans).
+
+// customField is a custom field as returned inline on Device42 objects.
+type customField struct {
+	Key   interface{} `json:"key,omitempty"`
+	Notes interface{} `json:"notes,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ipamVlan is models.IPAMvlans with custom fields.
+type ipamVlan struct {
+	models.IPAMvlans
+	CustomFields []*customField `json:"custom_fields"`
+}
+
+type ipamVlansBody struct {
+	Vlans      []*ipamVlan `json:"vlans"`
+	TotalCount interface{} `json:"total_count,omitempty"`
+}
+
+type ipamVlansReader struct{}
+
+func (r *ipamVlansReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return nil, runtime.NewAPIError("getIPAMvlans", response, response.Code())
+	}
+
+	result := new(ipamVlansBody)
+
+	if err := consumer.Consume(response.Body(), result); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getIPAMvlans is client.IPam.GetIPAMvlans keeping the vlan custom fields,
+// reading every page of them.
+func getIPAMvlans(ctx context.Context, c *client.Device42, params *ipam.GetIPAMvlansParams) ([]*ipamVlan, error) {
+	vlans := make([]*ipamVlan, 0)
+
+	for {
+		page, total, err := getIPAMvlansPage(ctx, c, params, len(vlans))
+
+		if err != nil {
+			return nil, err
+		}
+
+		vlans = append(vlans, page...)
+
+		if len(page) == 0 || len(vlans) >= total {
+			return vlans, nil
+		}
+	}
+}
+
+// getIPAMvlansPage returns a page of vlans and the total count.
+func getIPAMvlansPage(ctx context.Context, c *client.Device42, params *ipam.GetIPAMvlansParams, offset int) ([]*ipamVlan, int, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getIPAMvlans",
+		Method:             "GET",
+		PathPattern:        "/api/1.0/vlans/",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             &pagedParams{ClientRequestWriter: params, limit: ipamPageSize, offset: offset},
+		Reader:             &ipamVlansReader{},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body := result.(*ipamVlansBody)
+
+	return body.Vlans, intOrZero(body.TotalCount), nil
+}
+
+// ipamSwitchport is models.IPAMmacsPort with custom fields, nil when Device42
+// doesn't return them.
+type ipamSwitchport struct {
+	models.IPAMmacsPort
+	CustomFields []*customField `json:"custom_fields"`
+}
+
+type ipamSwitchportsBody struct {
+	Switchports []*ipamSwitchport `json:"switchports"`
+}
+
+type ipamSwitchportsReader struct{}
+
+func (r *ipamSwitchportsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return nil, runtime.NewAPIError("getIPAM_switchports", response, response.Code())
+	}
+
+	result := new(ipamSwitchportsBody)
+
+	if err := consumer.Consume(response.Body(), result); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getIPAMSwitchports is client.IPam.GetIPAMSwitchports keeping the port custom
+// fields.
+func getIPAMSwitchports(ctx context.Context, c *client.Device42, params *ipam.GetIPAMSwitchportsParams) ([]*ipamSwitchport, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getIPAM_switchports",
+		Method:             "GET",
+		PathPattern:        "/api/1.0/switchports",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &ipamSwitchportsReader{},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*ipamSwitchportsBody).Switchports, nil
+}
+
+// ipamPageSize is the number of objects requested per page when listing
+// subnets, IPs or vlans.
+const ipamPageSize = 1000
+
+// devicesPageSize is the number of devices requested per page, their full
+// details are much larger than subnets or IPs.
+const devicesPageSize = 100
+
+// pagedParams adds Device42's `limit`/`offset` paging to generated params
+// that lack it.
+type pagedParams struct {
+	runtime.ClientRequestWriter
+	limit  int
+	offset int
+}
+
+func (p *pagedParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	if err := p.ClientRequestWriter.WriteToRequest(r, reg); err != nil {
+		return err
+	}
+	if err := r.SetQueryParam("limit", strconv.Itoa(p.limit)); err != nil {
+		return err
+	}
+	return r.SetQueryParam("offset", strconv.Itoa(p.offset))
+}
+
+type ipamSubnetsBody struct {
+	Subnets    []*models.IPAMsubnets `json:"subnets"`
+	TotalCount interface{}           `json:"total_count,omitempty"`
+}
+
+type ipamSubnetsReader struct{}
+
+func (r *ipamSubnetsReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return nil, runtime.NewAPIError("getIPAMsubnets", response, response.Code())
+	}
+
+	result := new(ipamSubnetsBody)
+
+	if err := consumer.Consume(response.Body(), result); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getIPAMsubnetsPage is client.IPam.GetIPAMsubnets with the paging its params
+// lack, returning a page of subnets and the total count.
+func getIPAMsubnetsPage(ctx context.Context, c *client.Device42, params *ipam.GetIPAMsubnetsParams, offset int) ([]*models.IPAMsubnets, int, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getIPAMsubnets",
+		Method:             "GET",
+		PathPattern:        "/api/1.0/subnets/",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             &pagedParams{ClientRequestWriter: params, limit: ipamPageSize, offset: offset},
+		Reader:             &ipamSubnetsReader{},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body := result.(*ipamSubnetsBody)
+
+	return body.Subnets, intOrZero(body.TotalCount), nil
+}
+
+type devicesAllBody struct {
+	Devices    []*models.DevicesAll `json:"Devices"`
+	TotalCount interface{}          `json:"total_count,omitempty"`
+}
+
+type devicesAllReader struct{}
+
+func (r *devicesAllReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return nil, runtime.NewAPIError("getDevicesAll", response, response.Code())
+	}
+
+	result := new(devicesAllBody)
+
+	if err := consumer.Consume(response.Body(), result); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// getDevicesAllPage is client.Devices.GetDevicesAll with the filters of
+// GetDevices and paging, returning a page of full device details and the total
+// count. The generated operation takes neither and reads a single device.
+func getDevicesAllPage(ctx context.Context, c *client.Device42, params *devices.GetDevicesParams, offset int) ([]*models.DevicesAll, int, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getDevicesAll",
+		Method:             "GET",
+		PathPattern:        "/api/1.0/devices/all/",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             &pagedParams{ClientRequestWriter: params, limit: devicesPageSize, offset: offset},
+		Reader:             &devicesAllReader{},
+		Context:            ctx,
+		Client:             params.HTTPClient,
+	})
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	body := result.(*devicesAllBody)
+
+	return body.Devices, intOrZero(body.TotalCount), nil
+}
+
+// customFieldValue returns the value of the custom field with the given key.
+func customFieldValue(fields []*customField, key string) (string, bool) {
+	for _, f := range fields {
+		if f == nil {
+			continue
+		}
+		if k, ok := f.Key.(string); ok && k == key {
+			if f.Value == nil {
+				return "", true
+			}
+			return fmt.Sprint(f.Value), true
+		}
+	}
+	return "", false
+}
+
+// formParams writes a plain set of form values, for the endpoints that are
+// called without generated params.
+type formParams map[string]string
+
+func (p formParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := r.SetFormParam(k, p[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type codeMsgBody struct {
+	Code interface{} `json:"code,omitempty"`
+	Msg  interface{} `json:"msg,omitempty"`
+}
+
+type codeMsgReader struct {
+	id string
+}
+
+func (r *codeMsgReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return nil, runtime.NewAPIError(r.id, response, response.Code())
+	}
+
+	result := new(codeMsgBody)
+
+	if err := consumer.Consume(response.Body(), result); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// postDeviceRack is client.Devices.PostDeviceRack with the `orientation`
+// form param the generated params lack.
+func postDeviceRack(ctx context.Context, c *client.Device42, params formParams) error {
+	return submitForm(ctx, c, "postDeviceRack", "POST", "/api/1.0/device/rack/", params)
+}
+
+// postSwitchport creates or updates a switch port. The generated operation
+// only takes a single `vlan_id`, trunk ports need `vlan_ids`.
+func postSwitchport(ctx context.Context, c *client.Device42, params formParams) (string, error) {
+	msg, err := submitFormMsg(ctx, c, "postSwitchport", "POST", "/api/1.0/switchports/", params)
+	if err != nil {
+		return "", err
+	}
+	if len(msg) < 2 {
+		return "", fmt.Errorf("unexpected response %v", msg)
+	}
+	return msg[1], nil
+}
+
+// submitForm sends params to an endpoint answering with a code and message,
+// a non-zero code is returned as error.
+func submitForm(ctx context.Context, c *client.Device42, id, method, path string, params formParams) error {
+	_, err := submitFormMsg(ctx, c, id, method, path, params)
+	return err
+}
+
+// submitFormMsg is submitForm returning the message, e.g. `["added", id, name]`
+// for posts that create an object.
+func submitFormMsg(ctx context.Context, c *client.Device42, id, method, path string, params formParams) ([]string, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &codeMsgReader{id: id},
+		Context:            ctx,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := result.(*codeMsgBody)
+
+	if j_code, ok := body.Code.(json.Number); ok {
+		if code, _ := j_code.Int64(); code != 0 {
+			return nil, fmt.Errorf("%v", body.Msg)
+		}
+	}
+
+	msg, _ := body.Msg.([]interface{})
+
+	return intList(msg), nil
+}
+
+// queryParams writes a plain set of query values.
+type queryParams map[string]string
+
+func (p queryParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	for k, v := range p {
+		if err := r.SetQueryParam(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonReader struct {
+	id     string
+	result interface{}
+}
+
+func (r *jsonReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() == 404 {
+		return nil, &notFoundError{id: r.id}
+	}
+	if response.Code() != 200 {
+		return nil, runtime.NewAPIError(r.id, response, response.Code())
+	}
+
+	if err := consumer.Consume(response.Body(), r.result); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return r.result, nil
+}
+
+// notFoundError is returned by the operations below on a 404.
+type notFoundError struct {
+	id string
+}
+
+func (e *notFoundError) Error() string {
+	return fmt.Sprintf("[%s] not found", e.id)
+}
+
+// dnsZone is a DNS zone, the generated client can only create them.
+type dnsZone struct {
+	ID         interface{} `json:"id,omitempty"`
+	Name       interface{} `json:"name,omitempty"`
+	Nameserver interface{} `json:"nameserver,omitempty"`
+	Notes      interface{} `json:"notes,omitempty"`
+	Tags       interface{} `json:"tags,omitempty"`
+	VrfGroup   interface{} `json:"vrf_group,omitempty"`
+}
+
+type dnsZonesBody struct {
+	Zones []*dnsZone `json:"zones"`
+}
+
+// getDNSZones lists the DNS zones, filtered by the given query params.
+func getDNSZones(ctx context.Context, c *client.Device42, params queryParams) ([]*dnsZone, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getDNSZones",
+		Method:             "GET",
+		PathPattern:        "/api/1.0/dns/zones/",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             params,
+		Reader:             &jsonReader{id: "getDNSZones", result: new(dnsZonesBody)},
+		Context:            ctx,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*dnsZonesBody).Zones, nil
+}
+
+type deletedBody struct {
+	Deleted interface{} `json:"deleted,omitempty"`
+	ID      interface{} `json:"id,omitempty"`
+}
+
+// deleteDNSZone deletes a DNS zone, reporting whether Device42 did.
+func deleteDNSZone(ctx context.Context, c *client.Device42, id string) (bool, error) {
+	result, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "deleteDNSZone",
+		Method:             "DELETE",
+		PathPattern:        fmt.Sprintf("/api/1.0/dns/zones/%s/", id),
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/x-www-form-urlencoded"},
+		Schemes:            []string{"http", "https"},
+		Params:             queryParams{},
+		Reader:             &jsonReader{id: "deleteDNSZone", result: new(deletedBody)},
+		Context:            ctx,
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	b, _ := parseYesNo(result.(*deletedBody).Deleted)
+
+	return b, nil
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/models"
+)
+
+// Device42 objects can carry any number of custom fields, most of them set by
+// other teams or integrations. Only the keys declared in configuration are
+// tracked so the rest don't show up as drift.
+
+func customFieldsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Custom fields. Only the keys set here are tracked.",
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// putCustomFieldFunc sets (or clears) a single custom field on an object.
+type putCustomFieldFunc func(key, value string, clear bool) error
+
+// updateCustomFields writes the custom fields that changed in configuration,
+// keys removed from configuration are cleared.
+func updateCustomFields(d *schema.ResourceData, put putCustomFieldFunc) diag.Diagnostics {
+	if !d.HasChange("custom_fields") {
+		return nil
+	}
+
+	o, n := d.GetChange("custom_fields")
+	old := o.(map[string]interface{})
+	new := n.(map[string]interface{})
+
+	keys := make([]string, 0, len(new))
+	for k := range new {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if v, ok := old[k]; ok && v == new[k] {
+			continue
+		}
+		if err := put(k, new[k].(string), false); err != nil {
+			return diag.Errorf("error setting custom field %s. %s", k, err)
+		}
+	}
+
+	for k := range old {
+		if _, ok := new[k]; ok {
+			continue
+		}
+		if err := put(k, "", true); err != nil {
+			return diag.Errorf("error clearing custom field %s. %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// flattenCustomFields returns the values of the configured custom field keys.
+func flattenCustomFields(fields []*customField, keys map[string]interface{}) map[string]string {
+	m := make(map[string]string)
+	for k := range keys {
+		if v, ok := customFieldValue(fields, k); ok {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// customFieldList converts custom fields the generated models leave untyped.
+func customFieldList(v interface{}) []*customField {
+	l, _ := v.([]interface{})
+	fields := make([]*customField, 0, len(l))
+	for _, f := range l {
+		if m, ok := f.(map[string]interface{}); ok {
+			fields = append(fields, &customField{Key: m["key"], Notes: m["notes"], Value: m["value"]})
+		}
+	}
+	return fields
+}
+
+// customFieldObject is an object type custom fields can be set on. Every type
+// has its own endpoint, which selects the object by ID, name or address.
+type customFieldObject struct {
+	path string
+	// params returns the form params selecting the object.
+	params func(ctx context.Context, meta interface{}, id string) (formParams, diag.Diagnostics)
+	// fields returns the custom fields of the object, nil when Device42
+	// doesn't return them.
+	fields func(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics)
+}
+
+var customFieldObjects = map[string]customFieldObject{
+	"building":    {"/api/1.0/custom_fields/building/", customFieldObjectID, buildingCustomFieldsByID},
+	"customer":    {"/api/1.0/custom_fields/customer/", customerCustomFieldParams, customerCustomFieldsByID},
+	"device":      {"/api/1.0/device/custom_field/", customFieldObjectID, deviceCustomFieldsByID},
+	"ip_address":  {"/api/1.0/custom_fields/ip_address/", ipamIPCustomFieldParams, ipamIPCustomFieldsByID},
+	"rack":        {"/api/1.0/custom_fields/rack/", customFieldObjectID, rackCustomFieldsByID},
+	"room":        {"/api/1.0/custom_fields/room/", customFieldObjectID, roomCustomFieldsByID},
+	"subnet":      {"/api/1.0/custom_fields/subnet/", ipamSubnetCustomFieldParams, ipamSubnetCustomFieldsByID},
+	"switch_vlan": {"/api/1.0/custom_fields/switch_vlan/", customFieldObjectID, ipamVlanCustomFieldsByID},
+	"switchport":  {"/api/1.0/custom_fields/switchport/", customFieldObjectID, switchPortCustomFieldsByID},
+}
+
+func customFieldObjectTypes() []string {
+	l := make([]string, 0, len(customFieldObjects))
+	for k := range customFieldObjects {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+	return l
+}
+
+func customFieldObjectID(ctx context.Context, meta interface{}, id string) (formParams, diag.Diagnostics) {
+	return formParams{"id": id}, nil
+}
+
+// setCustomField sends params to the custom field endpoint of an object.
+func setCustomField(ctx context.Context, meta interface{}, object, id string, params formParams) diag.Diagnostics {
+	o, ok := customFieldObjects[object]
+	if !ok {
+		return diag.Errorf("error custom fields aren't supported on %s.", object)
+	}
+
+	selector, diags := o.params(ctx, meta, id)
+	if diags != nil {
+		return diags
+	}
+	for k, v := range selector {
+		params[k] = v
+	}
+
+	if err := submitForm(ctx, meta.(*apiClient).Device42, "putCustomField", "PUT", o.path, params); err != nil {
+		return diag.Errorf("error setting custom field %s on %s %s. %s", params["key"], object, id, err)
+	}
+
+	return nil
+}
+
+// objectPutCustomField returns a putCustomFieldFunc for any object type in
+// customFieldObjects.
+func objectPutCustomField(ctx context.Context, meta interface{}, object, id string) putCustomFieldFunc {
+	return func(key, value string, clear bool) error {
+		params := formParams{"key": key}
+		if clear {
+			params["clear_value"] = "yes"
+		} else {
+			params["value"] = value
+		}
+		if diags := setCustomField(ctx, meta, object, id, params); diags != nil {
+			return fmt.Errorf("%s", diags[0].Summary)
+		}
+		return nil
+	}
+}
+
+func buildingCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	building, diags := getBuilding(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	if building == nil {
+		return nil, diag.Errorf("error building %s not found.", id)
+	}
+	return customFieldList(building.CustomFields), nil
+}
+
+func customerCustomFieldParams(ctx context.Context, meta interface{}, id string) (formParams, diag.Diagnostics) {
+	name, diags := customerName(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	if name == "" {
+		return nil, diag.Errorf("error customer %s not found.", id)
+	}
+	return formParams{"name": name}, nil
+}
+
+func customerCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	customer, diags := getCustomer(ctx, meta, func(c *models.Customers) bool {
+		return stringOrNumber(c.ID) == id
+	})
+	if diags != nil {
+		return nil, diags
+	}
+	if customer == nil {
+		return nil, diag.Errorf("error customer %s not found.", id)
+	}
+	return customerCustomFields(customer), nil
+}
+
+func deviceCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	device, diags := getDevice(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	return deviceCustomFields(device.CustomFields), nil
+}
+
+// switchPortCustomFieldsByID returns the custom fields of a switch port, nil
+// when Device42 doesn't return them.
+func switchPortCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	port, diags := getSwitchPort(ctx, meta, "", id)
+	if diags != nil {
+		return nil, diags
+	}
+	if port == nil {
+		return nil, diag.Errorf("error switch port %s not found.", id)
+	}
+	return port.CustomFields, nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/buildings"
+)
+
+func dataSourceBuilding() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read building.",
+
+		ReadContext: dataSourceBuildingRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Building name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"address": {
+				Description: "Address.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"building_id": {
+				Description: "Building ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"contact_name": {
+				Description: "Contact name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceBuildingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := buildings.NewGetBuildingsParamsWithContext(ctx)
+
+	name := d.Get("name").(string)
+	params.SetName(&name)
+
+	resp, err := client.Buildings.GetBuildings(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error retrieving buildings. %s", err)
+	}
+
+	if len(resp.Payload.Buildings) == 0 {
+		return diag.Errorf("error building not found.")
+	}
+
+	if len(resp.Payload.Buildings) > 1 {
+		return diag.Errorf("error more than one building found.")
+	}
+
+	building := resp.Payload.Buildings[0]
+	id := stringOrNumber(building.BuildingID)
+
+	d.Set("address", stringOrEmpty(building.Address))
+	d.Set("building_id", id)
+	d.Set("contact_name", stringOrEmpty(building.ContactName))
+	d.Set("notes", stringOrEmpty(building.Notes))
+
+	d.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/models"
+)
+
+func dataSourceCustomer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read customer.",
+
+		ReadContext: dataSourceCustomerRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Customer name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"contact_info": {
+				Description: "Contact info.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"custom_fields": {
+				Description: "Custom fields.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"customer_id": {
+				Description: "Customer ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceCustomerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	name := d.Get("name").(string)
+
+	customer, diags := getCustomer(ctx, meta, func(c *models.Customers) bool {
+		return stringOrEmpty(c.Name) == name
+	})
+	if diags != nil {
+		return diags
+	}
+
+	if customer == nil {
+		return diag.Errorf("error customer not found.")
+	}
+
+	id := stringOrNumber(customer.ID)
+
+	d.Set("contact_info", stringOrEmpty(customer.ContactInfo))
+	d.Set("customer_id", id)
+	d.Set("notes", stringOrEmpty(customer.Notes))
+
+	fields := customerCustomFields(customer)
+	custom_fields := make(map[string]string)
+	for _, f := range fields {
+		if k, ok := f.Key.(string); ok {
+			custom_fields[k], _ = customFieldValue(fields, k)
+		}
+	}
+	d.Set("custom_fields", custom_fields)
+
+	d.SetId(id)
+
+	return nil
+}
@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/devices"
+	"github.com/poroping/libdevice42/models"
+)
+
+func dataSourceDevice() *schema.Resource {
+	s := dataSourceDeviceSchema()
+
+	s["device_id"].Optional = true
+	s["device_id"].ExactlyOneOf = []string{"device_id", "name", "serial_no"}
+	s["name"].Optional = true
+	s["serial_no"].Optional = true
+
+	return &schema.Resource{
+		Description: "Read device.",
+
+		ReadContext: dataSourceDeviceRead,
+
+		Schema: s,
+	}
+}
+
+// dataSourceDeviceSchema returns the attributes of a device as read by the
+// device data sources.
+func dataSourceDeviceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"device_id": {
+			Description: "Device ID.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"name": {
+			Description: "Device name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"serial_no": {
+			Description: "Serial number.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"type": {
+			Description: "Device type.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"asset_no": {
+			Description: "Asset number.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"custom_fields": {
+			Description: "Custom fields.",
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"customer": {
+			Description: "Customer name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"hardware": {
+			Description: "Hardware model name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"notes": {
+			Description: "Notes.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"os": {
+			Description: "Operating system name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"service_level": {
+			Description: "Service level.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"tags": {
+			Description: "Tags.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"virtual_host": {
+			Description: "Name of the virtual host.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"building": {
+			Description: "Building name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"room": {
+			Description: "Room name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"rack": {
+			Description: "Rack name.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"rack_id": {
+			Description: "Rack ID.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"start_at": {
+			Description: "Lowest rack unit the device is mounted at.",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"ip_addresses": {
+			Description: "IP addresses of the device.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ip": {
+						Description: "IP address.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"label": {
+						Description: "Label of the interface.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"macaddress": {
+						Description: "MAC address.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"subnet_id": {
+						Description: "Subnet ID.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"type": {
+						Description: "IP type.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"mac_addresses": {
+			Description: "MAC addresses of the device.",
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"macaddress": {
+						Description: "MAC address.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"port_name": {
+						Description: "Name of the port.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+					"vlan": {
+						Description: "VLAN.",
+						Type:        schema.TypeString,
+						Computed:    true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDeviceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	id := d.Get("device_id").(string)
+
+	if id == "" {
+		params := devices.NewGetDevicesParamsWithContext(ctx)
+
+		if v, ok := d.GetOk("name"); ok {
+			s := v.(string)
+			params.SetName(&s)
+		}
+		if v, ok := d.GetOk("serial_no"); ok {
+			s := v.(string)
+			params.SetSerialNo(&s)
+		}
+
+		resp, err := client.Devices.GetDevices(params, nil)
+
+		if err != nil {
+			return diag.Errorf("error retrieving devices. %s", err)
+		}
+
+		if len(resp.Payload.Devices) == 0 {
+			return diag.Errorf("error device not found.")
+		}
+
+		if len(resp.Payload.Devices) > 1 {
+			return diag.Errorf("error more than one device found.")
+		}
+
+		id = fmt.Sprint(resp.Payload.Devices[0].DeviceID)
+	}
+
+	device, diags := getDevice(ctx, meta, id)
+	if diags != nil {
+		return diags
+	}
+
+	for k, v := range flattenDevice(device) {
+		d.Set(k, v)
+	}
+
+	d.SetId(id)
+
+	return nil
+}
+
+// getDevice returns the full details of a device.
+func getDevice(ctx context.Context, meta interface{}, device_id string) (*models.DevicesAll, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	i, err := strconv.ParseInt(device_id, 10, 64)
+	if err != nil {
+		return nil, diag.Errorf("error getting device ID. %s", err)
+	}
+
+	params := devices.NewGetDevicesIDParamsWithContext(ctx)
+	params.SetDeviceID(i)
+
+	resp, err := client.Devices.GetDevicesID(params, nil)
+
+	if err != nil {
+		return nil, diag.Errorf("error reading device %s. %s", device_id, err)
+	}
+
+	return resp.Payload, nil
+}
+
+// flattenDevice returns the attributes of dataSourceDeviceSchema.
+func flattenDevice(device *models.DevicesAll) map[string]interface{} {
+	m := map[string]interface{}{
+		"name":          stringOrEmpty(device.Name),
+		"serial_no":     stringOrEmpty(device.SerialNo),
+		"type":          stringOrEmpty(device.Type),
+		"asset_no":      stringOrEmpty(device.AssetNo),
+		"customer":      stringOrEmpty(device.Customer),
+		"hardware":      stringOrEmpty(device.HwModel),
+		"notes":         stringOrEmpty(device.Notes),
+		"os":            stringOrEmpty(device.Os),
+		"service_level": stringOrEmpty(device.ServiceLevel),
+		"tags":          flattenTagList(device.Tags),
+		"virtual_host":  stringOrEmpty(device.VirtualHostName),
+		"building":      stringOrEmpty(device.Building),
+		"room":          stringOrEmpty(device.Room),
+		"rack":          stringOrEmpty(device.Rack),
+		"rack_id":       stringOrNumber(device.RackID),
+		"start_at":      stringOrNumber(device.StartAt),
+	}
+
+	if device.DeviceID != nil {
+		m["device_id"] = fmt.Sprint(device.DeviceID)
+	} else {
+		m["device_id"] = stringOrNumber(device.ID)
+	}
+
+	custom_fields := make(map[string]string)
+	for _, f := range device.CustomFields {
+		if f == nil {
+			continue
+		}
+		if k, ok := f.Key.(string); ok {
+			custom_fields[k] = stringOrNumber(f.Value)
+		}
+	}
+	m["custom_fields"] = custom_fields
+
+	ips := make([]interface{}, 0, len(device.IPAddresses))
+	for _, ip := range device.IPAddresses {
+		if ip == nil {
+			continue
+		}
+		ips = append(ips, map[string]interface{}{
+			"ip":         stringOrEmpty(ip.IP),
+			"label":      stringOrEmpty(ip.Label),
+			"macaddress": stringOrEmpty(ip.Macaddress),
+			"subnet_id":  stringOrNumber(ip.SubnetID),
+			"type":       stringOrEmpty(ip.Type),
+		})
+	}
+	m["ip_addresses"] = ips
+
+	macs := make([]interface{}, 0, len(device.MacAddresses))
+	for _, mac := range device.MacAddresses {
+		if mac == nil {
+			continue
+		}
+		macs = append(macs, map[string]interface{}{
+			"macaddress": stringOrEmpty(mac.Mac),
+			"port_name":  stringOrEmpty(mac.PortName),
+			"vlan":       stringOrNumber(mac.Vlan),
+		})
+	}
+	m["mac_addresses"] = macs
+
+	return m
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poroping/libdevice42/models"
+)
+
+func TestFlattenDevice(t *testing.T) {
+	device := &models.DevicesAll{
+		DeviceID: json.Number("7"),
+		Name:     "web-01",
+		Type:     "virtual",
+		Tags:     []interface{}{"prod", "web"},
+		HwModel:  "R640",
+		Rack:     "R1",
+		RackID:   json.Number("3"),
+		CustomFields: models.DeviceAllCustomField{
+			{Key: "owner", Value: "ops"},
+			{Key: "cost", Value: json.Number("12")},
+			nil,
+		},
+		IPAddresses: []*models.DeviceAllIPAddresses{
+			{IP: "10.0.0.1", Label: "eth0", SubnetID: json.Number("4")},
+		},
+		MacAddresses: []*models.DeviceAllMacAddresses{
+			{Mac: "02:00:00:00:00:01", PortName: "eth0"},
+		},
+	}
+
+	m := flattenDevice(device)
+
+	for k, want := range map[string]interface{}{
+		"device_id": "7",
+		"name":      "web-01",
+		"type":      "virtual",
+		"tags":      "prod,web",
+		"hardware":  "R640",
+		"rack":      "R1",
+		"rack_id":   "3",
+		"customer":  "",
+		"start_at":  "",
+		"custom_fields": map[string]string{
+			"owner": "ops",
+			"cost":  "12",
+		},
+		"ip_addresses": []interface{}{
+			map[string]interface{}{"ip": "10.0.0.1", "label": "eth0", "macaddress": "", "subnet_id": "4", "type": ""},
+		},
+		"mac_addresses": []interface{}{
+			map[string]interface{}{"macaddress": "02:00:00:00:00:01", "port_name": "eth0", "vlan": ""},
+		},
+	} {
+		if !reflect.DeepEqual(m[k], want) {
+			t.Errorf("%s = %#v, want %#v", k, m[k], want)
+		}
+	}
+
+	// device details read by ID have `id` rather than `device_id`.
+	if got := flattenDevice(&models.DevicesAll{ID: json.Number("8")})["device_id"]; got != "8" {
+		t.Errorf("device_id = %v, want 8", got)
+	}
+}
+
+func TestDataSourceDevicesID(t *testing.T) {
+	a := dataSourceDevicesID(map[string]string{"type": "virtual", "tags": "web"})
+	b := dataSourceDevicesID(map[string]string{"tags": "web", "type": "virtual"})
+	c := dataSourceDevicesID(map[string]string{"type": "virtual"})
+
+	if a != b {
+		t.Errorf("the same filters give different IDs %s and %s", a, b)
+	}
+	if a == c {
+		t.Errorf("different filters give the same ID %s", a)
+	}
+}
+
+func TestAccDataSourceDevice_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeviceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.device42_device.test", "device_id", "device42_device.test", "device_id"),
+					resource.TestCheckResourceAttr("data.device42_device.test", "name", name),
+					resource.TestCheckResourceAttr("data.device42_device.test", "tags", name),
+					resource.TestCheckResourceAttr("data.device42_devices.test", "devices.#", "1"),
+					resource.TestCheckResourceAttrPair("data.device42_devices.test", "devices.0.device_id", "device42_device.test", "device_id"),
+					resource.TestCheckResourceAttr("data.device42_devices.test", "devices.0.name", name),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDeviceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "device42_device" "test" {
+  name = "%[1]s"
+  type = "virtual"
+  tags = "%[1]s"
+}
+
+data "device42_device" "test" {
+  name = device42_device.test.name
+
+  depends_on = [device42_device.test]
+}
+
+data "device42_devices" "test" {
+  type = "virtual"
+  tags = device42_device.test.tags
+
+  depends_on = [device42_device.test]
+}
+`, name)
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/devices"
+)
+
+func dataSourceDevices() *schema.Resource {
+	return &schema.Resource{
+		Description: "Search devices.",
+
+		ReadContext: dataSourceDevicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"building": {
+				Description: "Building name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"customer": {
+				Description: "Customer name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"hardware": {
+				Description: "Hardware model name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"os": {
+				Description: "Operating system name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"rack": {
+				Description: "Rack name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description: "Comma separated tags, devices with all of them.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": {
+				Description: "Device type, e.g. `physical`, `virtual` or `cluster`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"devices": {
+				Description: "Matching devices, with the same attributes as the `device42_device` data source.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourceDeviceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDevicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewGetDevicesParamsWithContext(ctx)
+	filters := make(map[string]string)
+
+	for k, set := range map[string]func(*string){
+		"building": params.SetBuilding,
+		"customer": params.SetCustomer,
+		"hardware": params.SetHardware,
+		"os":       params.SetOs,
+		"rack":     params.SetRack,
+		"tags":     params.SetTagsAnd,
+		"type":     params.SetType,
+	} {
+		if v, ok := d.GetOk(k); ok {
+			s := v.(string)
+			set(&s)
+			filters[k] = s
+		}
+	}
+
+	list := make([]interface{}, 0)
+
+	// devices/all returns the full details the search doesn't, a page at a
+	// time.
+	for read := 0; ; {
+		page, total, err := getDevicesAllPage(ctx, client, params, read)
+
+		if err != nil {
+			return diag.Errorf("error retrieving devices. %s", err)
+		}
+
+		for _, device := range page {
+			if device != nil {
+				list = append(list, flattenDevice(device))
+			}
+		}
+
+		read += len(page)
+
+		if len(page) == 0 || read >= total {
+			break
+		}
+	}
+
+	if err := d.Set("devices", list); err != nil {
+		return diag.Errorf("error setting devices. %s", err)
+	}
+
+	d.SetId(dataSourceDevicesID(filters))
+
+	return nil
+}
+
+// dataSourceDevicesID identifies a search by its filters rather than the
+// devices found, which change over time.
+func dataSourceDevicesID(filters map[string]string) string {
+	l := make([]string, 0, len(filters))
+	for k, v := range filters {
+		l = append(l, k+"="+v)
+	}
+	sort.Strings(l)
+
+	return fmt.Sprintf("devices/%d", schema.HashString(strings.Join(l, "&")))
+}
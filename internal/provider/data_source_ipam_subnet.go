@@ -8,7 +8,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/poroping/libdevice42/client"
 	ipam "github.com/poroping/libdevice42/client/ip_a_m"
 	"github.com/poroping/libdevice42/models"
 )
@@ -82,9 +81,9 @@ func dataSourceIpamSubnet() *schema.Resource {
 func dataSourceIpamSubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// use the meta value to retrieve your client from the provider configure method
 	// client := meta.(*apiClient)
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMSubnetIDParams()
+	params := ipam.NewGetIPAMSubnetIDParamsWithContext(ctx)
 
 	id := d.Get("subnet_id").(string)
 
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+func dataSourceIpamVlan() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read IPAM vlan.",
+
+		ReadContext: dataSourceIpamVlanRead,
+
+		Schema: map[string]*schema.Schema{
+			"custom_fields": {
+				Description: "Custom fields.",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "Name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"number": {
+				Description: "VLAN number.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"switch_ids": {
+				Description: "Comma separated device IDs of the switches the VLAN is linked to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"switch_names": {
+				Description: "Comma separated names of the switches the VLAN is linked to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"tags": {
+				Description: "Tags.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"vlan_id": {
+				Description: "VLAN ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
+}
+
+func dataSourceIpamVlanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMvlansParamsWithContext(ctx)
+
+	id := d.Get("vlan_id").(string)
+	params.SetVlanID(&id)
+
+	vlans, err := getIPAMvlans(ctx, client, params)
+
+	if err != nil {
+		return diag.Errorf("error retrieving IPAM vlans. %s", err)
+	}
+
+	if len(vlans) == 0 {
+		return diag.Errorf("error vlan ID not found.")
+	}
+
+	if len(vlans) > 1 {
+		return diag.Errorf("error more than one vlan found.")
+	}
+
+	dataSetIpamVlan(d, vlans[0])
+
+	d.SetId(id)
+
+	return nil
+}
+
+func dataSetIpamVlan(d *schema.ResourceData, resp *ipamVlan) {
+	if v, ok := resp.Description.(string); ok {
+		d.Set("description", v)
+	}
+	if v, ok := resp.Name.(string); ok {
+		d.Set("name", v)
+	}
+	if v, ok := resp.Notes.(string); ok {
+		d.Set("notes", v)
+	}
+	if v, ok := resp.Number.(json.Number); ok {
+		d.Set("number", v.String())
+	}
+	if v := resp.Tags; v != nil {
+		d.Set("tags", strings.Join(v, ","))
+	}
+
+	ids := make([]string, 0, len(resp.Switches))
+	names := make([]string, 0, len(resp.Switches))
+	for _, sw := range resp.Switches {
+		if sw == nil {
+			continue
+		}
+		ids = append(ids, fmt.Sprint(sw.DeviceID))
+		if v, ok := sw.Name.(string); ok {
+			names = append(names, v)
+		}
+	}
+	d.Set("switch_ids", strings.Join(ids, ","))
+	d.Set("switch_names", strings.Join(names, ","))
+
+	custom_fields := make(map[string]string)
+	for _, f := range resp.CustomFields {
+		if f == nil {
+			continue
+		}
+		if k, ok := f.Key.(string); ok {
+			custom_fields[k], _ = customFieldValue(resp.CustomFields, k)
+		}
+	}
+	d.Set("custom_fields", custom_fields)
+}
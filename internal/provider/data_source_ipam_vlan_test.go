@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/models"
+)
+
+func TestDataSetIpamVlan(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceIpamVlan().Schema, map[string]interface{}{"vlan_id": "5"})
+
+	dataSetIpamVlan(d, &ipamVlan{
+		IPAMvlans: models.IPAMvlans{
+			Name:   "WAN",
+			Number: json.Number("100"),
+			Tags:   []string{"a", "b"},
+			Switches: []*models.IPAMvlansSwitchesItems0{
+				{DeviceID: json.Number("10"), Name: "sw1"},
+				nil,
+				{DeviceID: json.Number("11")},
+			},
+		},
+		CustomFields: []*customField{
+			{Key: "domain", Value: "DC-01"},
+			nil,
+		},
+	})
+
+	for k, want := range map[string]string{
+		"name":                 "WAN",
+		"number":               "100",
+		"tags":                 "a,b",
+		"switch_ids":           "10,11",
+		"switch_names":         "sw1",
+		"custom_fields.domain": "DC-01",
+	} {
+		if got := d.Get(k); got != want {
+			t.Errorf("%s = %#v, want %#v", k, got, want)
+		}
+	}
+}
+
+func TestAccDataSourceIpamVlan_basic(t *testing.T) {
+	number := acctest.RandIntRange(2000, 3000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceIpamVlanConfig(number),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.device42_ipam_vlan.test", "vlan_id", "device42_ipam_vlan.test", "vlan_id"),
+					resource.TestCheckResourceAttr("data.device42_ipam_vlan.test", "name", "TF-ACC-TEST-VLAN"),
+					resource.TestCheckResourceAttr("data.device42_ipam_vlan.test", "number", fmt.Sprint(number)),
+					resource.TestCheckResourceAttr("data.device42_ipam_vlan.test", "switch_ids", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceIpamVlanConfig(number int) string {
+	return testAccResourceIpamVlanConfig(number, "") + `
+data "device42_ipam_vlan" "test" {
+  vlan_id = device42_ipam_vlan.test.vlan_id
+
+  depends_on = [device42_ipam_vlan.test]
+}
+`
+}
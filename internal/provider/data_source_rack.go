@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/racks"
+)
+
+func dataSourceRack() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read rack.",
+
+		ReadContext: dataSourceRackRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Rack name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"building": {
+				Description: "Name of the building the rack is in.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"room": {
+				Description: "Name of the room the rack is in.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"room_id": {
+				Description: "ID of the room the rack is in.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"available_u": {
+				Description: "Number of free U.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"manufacturer": {
+				Description: "Manufacturer name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"rack_id": {
+				Description: "Rack ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"row": {
+				Description: "Name of the row the rack is in.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"size": {
+				Description: "Height in U.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"tags": {
+				Description: "Tags.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceRackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := racks.NewGetRacksParamsWithContext(ctx)
+
+	name := d.Get("name").(string)
+	params.SetName(&name)
+
+	if v, ok := d.GetOk("building"); ok {
+		s := v.(string)
+		params.SetBuilding(&s)
+	}
+	if v, ok := d.GetOk("room"); ok {
+		s := v.(string)
+		params.SetRoom(&s)
+	}
+	if v, ok := d.GetOk("room_id"); ok {
+		s := v.(string)
+		params.SetRoomID(&s)
+	}
+
+	resp, err := client.Racks.GetRacks(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error retrieving racks. %s", err)
+	}
+
+	if len(resp.Payload.Racks) == 0 {
+		return diag.Errorf("error rack not found.")
+	}
+
+	if len(resp.Payload.Racks) > 1 {
+		return diag.Errorf("error more than one rack found.")
+	}
+
+	rack := resp.Payload.Racks[0]
+	id := stringOrNumber(rack.RackID)
+
+	d.Set("building", stringOrEmpty(rack.Building))
+	d.Set("room", stringOrEmpty(rack.Room))
+	d.Set("available_u", intOrZero(rack.Availableu))
+	d.Set("manufacturer", stringOrEmpty(rack.Manufacturer))
+	d.Set("notes", stringOrEmpty(rack.Notes))
+	d.Set("rack_id", id)
+	d.Set("row", stringOrEmpty(rack.Row))
+	d.Set("size", intOrZero(rack.Size))
+	d.Set("tags", flattenTagList(rack.Tags))
+
+	d.SetId(id)
+
+	return nil
+}
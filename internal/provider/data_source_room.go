@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/rooms"
+)
+
+func dataSourceRoom() *schema.Resource {
+	return &schema.Resource{
+		Description: "Read room.",
+
+		ReadContext: dataSourceRoomRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Room name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"building": {
+				Description: "Name of the building the room is in, required if the room name isn't unique.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"building_id": {
+				Description: "ID of the building the room is in.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"room_id": {
+				Description: "Room ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceRoomRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewGetRoomsParamsWithContext(ctx)
+
+	name := d.Get("name").(string)
+	params.SetName(&name)
+
+	if v, ok := d.GetOk("building"); ok {
+		s := v.(string)
+		params.SetBuilding(&s)
+	}
+
+	resp, err := client.Rooms.GetRooms(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error retrieving rooms. %s", err)
+	}
+
+	if len(resp.Payload.Rooms) == 0 {
+		return diag.Errorf("error room not found.")
+	}
+
+	if len(resp.Payload.Rooms) > 1 {
+		return diag.Errorf("error more than one room found.")
+	}
+
+	room := resp.Payload.Rooms[0]
+	id := stringOrNumber(room.RoomID)
+
+	d.Set("building", stringOrEmpty(room.Building))
+	d.Set("building_id", stringOrNumber(room.BuildingID))
+	d.Set("notes", stringOrEmpty(room.Notes))
+	d.Set("room_id", id)
+
+	d.SetId(id)
+
+	return nil
+}
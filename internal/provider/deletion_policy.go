@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// What destroy does with the Device42 object.
+const (
+	deletionPolicyDelete = "delete"
+	// only remove the object from state, handing it back to manual management
+	deletionPolicyAbandon = "abandon"
+)
+
+var deletionPolicies = []string{deletionPolicyDelete, deletionPolicyAbandon}
+
+func deletionPolicySchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description:  "What destroy does with the " + what + ". One of `delete` or `abandon` (only remove it from state). Defaults to the provider `deletion_policy`, or `abandon` for an adopted " + what + ".",
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice(deletionPolicies, false),
+	}
+}
+
+// deletionPolicy returns the policy destroy should follow. Adopted objects are
+// abandoned unless the resource says otherwise, and read-only adopted ones are
+// always left in place.
+func deletionPolicy(d *schema.ResourceData, meta interface{}) string {
+	adopted := ""
+	if v, ok := d.GetOk("adopted"); ok {
+		adopted = v.(string)
+	}
+
+	if adopted == onExistingAdoptReadonly {
+		return deletionPolicyAbandon
+	}
+
+	if v, ok := d.GetOk("deletion_policy"); ok {
+		return v.(string)
+	}
+
+	if adopted != "" {
+		return deletionPolicyAbandon
+	}
+
+	return meta.(*apiClient).deletionPolicy
+}
+
+// abandonOnDestroy reports whether destroy should leave the object in Device42.
+func abandonOnDestroy(d *schema.ResourceData, meta interface{}, what string) bool {
+	if deletionPolicy(d, meta) != deletionPolicyAbandon {
+		return false
+	}
+	log.Printf("[INFO] abandoning %s %s, removing it from state only", what, d.Id())
+	return true
+}
+
+func deletionProtectionSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Description: "Refuse to delete the " + what + " on destroy while set. Has to be turned off and applied before the " + what + " can be destroyed.",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// checkDeletionProtection fails destroy of a protected object.
+func checkDeletionProtection(d *schema.ResourceData, what string) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("error %s %s has deletion_protection set, turn it off before destroying it.", what, d.Id())
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDeletionPolicy(t *testing.T) {
+	var tests = []struct {
+		raw      map[string]interface{}
+		adopted  string
+		provider string
+		policy   string
+	}{
+		{map[string]interface{}{}, "", deletionPolicyDelete, deletionPolicyDelete},
+		{map[string]interface{}{}, "", deletionPolicyAbandon, deletionPolicyAbandon},
+		{map[string]interface{}{"deletion_policy": "delete"}, "", deletionPolicyAbandon, deletionPolicyDelete},
+		{map[string]interface{}{}, onExistingAdopt, deletionPolicyDelete, deletionPolicyAbandon},
+		{map[string]interface{}{"deletion_policy": "delete"}, onExistingAdopt, deletionPolicyDelete, deletionPolicyDelete},
+		{map[string]interface{}{"deletion_policy": "delete"}, onExistingAdoptReadonly, deletionPolicyDelete, deletionPolicyAbandon},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("Testing deletion policy, %v", i), func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceIpamSubnet().Schema, tt.raw)
+			d.Set("adopted", tt.adopted)
+			if got := deletionPolicy(d, &apiClient{deletionPolicy: tt.provider}); got != tt.policy {
+				t.Errorf("got %q, want %q", got, tt.policy)
+			}
+		})
+	}
+}
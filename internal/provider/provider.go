@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/poroping/libdevice42/client"
 )
 
@@ -49,14 +51,47 @@ func New(version string) func() *schema.Provider {
 					Optional: true,
 					Default:  false,
 				},
+				"request_timeout": {
+					Description:  "Timeout of a single API request, e.g. `30s` or `2m`. Resource `timeouts` bound the whole operation.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "30s",
+					ValidateFunc: validateDuration,
+				},
+				"deletion_policy": {
+					Description:  "Default `deletion_policy` of resources. One of `delete` or `abandon`.",
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      deletionPolicyDelete,
+					ValidateFunc: validation.StringInSlice(deletionPolicies, false),
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
+				"device42_building":    dataSourceBuilding(),
+				"device42_customer":    dataSourceCustomer(),
+				"device42_device":      dataSourceDevice(),
+				"device42_devices":     dataSourceDevices(),
 				"device42_ipam_subnet": dataSourceIpamSubnet(),
+				"device42_ipam_vlan":   dataSourceIpamVlan(),
+				"device42_rack":        dataSourceRack(),
+				"device42_room":        dataSourceRoom(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"device42_ipam_ip":     resourceIpamIP(),
-				"device42_ipam_subnet": resourceIpamSubnet(),
-				"device42_ipam_vlan":   resourceIpamVlan(),
+				"device42_building":     resourceBuilding(),
+				"device42_custom_field": resourceCustomField(),
+				"device42_customer":     resourceCustomer(),
+				"device42_device":       resourceDevice(),
+				"device42_dns_record":   resourceDNSRecord(),
+				"device42_dns_zone":     resourceDNSZone(),
+				"device42_ip_nat":       resourceIPNat(),
+				"device42_ipam_ip":      resourceIpamIP(),
+				"device42_ipam_subnet":  resourceIpamSubnet(),
+				"device42_ipam_vlan":    resourceIpamVlan(),
+				"device42_mac_address":  resourceMacAddress(),
+				"device42_rack":         resourceRack(),
+				"device42_rack_mount":   resourceRackMount(),
+				"device42_room":         resourceRoom(),
+				"device42_switch_port":  resourceSwitchPort(),
 			},
 		}
 
@@ -66,11 +101,23 @@ func New(version string) func() *schema.Provider {
 	}
 }
 
-// type apiClient struct {
-// 	// Add whatever fields, client or connection info, etc. here
-// 	// you would need to setup to communicate with the upstream
-// 	// API.
-// }
+// apiClient is the Device42 client along with the provider-wide defaults.
+type apiClient struct {
+	*client.Device42
+
+	// default `deletion_policy` of resources
+	deletionPolicy string
+}
+
+// resourceTimeouts are the default `timeouts` of resources.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(10 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Update: schema.DefaultTimeout(10 * time.Minute),
+		Delete: schema.DefaultTimeout(20 * time.Minute),
+	}
+}
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -86,6 +133,19 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			Schemes:  []string{"https"},
 		}, username, password, userAgent, insecure)
 
-		return c, diags
+		request_timeout, err := time.ParseDuration(d.Get("request_timeout").(string))
+		if err != nil {
+			return nil, diag.Errorf("error parsing request_timeout. %s", err)
+		}
+
+		c.SetTransport(&timeoutTransport{
+			ClientTransport: &clearingTransport{c.Transport},
+			timeout:         request_timeout,
+		})
+
+		return &apiClient{
+			Device42:       c,
+			deletionPolicy: d.Get("deletion_policy").(string),
+		}, diags
 	}
 }
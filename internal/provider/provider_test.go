@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -10,7 +11,7 @@ import (
 // The factory function will be invoked for every Terraform CLI command executed
 // to create a provider server to which the CLI can reattach.
 var providerFactories = map[string]func() (*schema.Provider, error){
-	"scaffolding": func() (*schema.Provider, error) {
+	"device42": func() (*schema.Provider, error) {
 		return New("dev")(), nil
 	},
 }
@@ -22,7 +23,9 @@ func TestProvider(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	for _, env := range []string{"TF_DEVICE42_HOST", "TF_DEVICE42_USERNAME", "TF_DEVICE42_PASSWORD"} {
+		if v := os.Getenv(env); v == "" {
+			t.Fatalf("%s must be set for acceptance tests", env)
+		}
+	}
 }
@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/buildings"
+	"github.com/poroping/libdevice42/models"
+)
+
+func resourceBuilding() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage buildings.",
+
+		CreateContext: resourceBuildingCreate,
+		ReadContext:   resourceBuildingRead,
+		UpdateContext: resourceBuildingUpdate,
+		DeleteContext: resourceBuildingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Building name. Buildings are keyed by name so changing it creates a new building.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"address": {
+				Description: "Address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"contact_name": {
+				Description: "Contact name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"contact_phone": {
+				Description: "Contact phone number. Device42 doesn't return it so changes made outside Terraform aren't detected.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"custom_fields": customFieldsSchema(),
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"building_id": {
+				Description: "Building ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("building"),
+		},
+	}
+}
+
+func resourceBuildingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := buildings.NewPostBuildingsParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+	params.Address = d.Get("address").(string)
+
+	// posting a building updates the one with the same name.
+	existing, diags := getBuildingByName(ctx, meta, params.Name)
+	if diags != nil {
+		return diags
+	}
+	if existing != nil {
+		return diag.Errorf("error building %s already exists with ID %s, import it to manage it.", params.Name, stringOrNumber(existing.BuildingID))
+	}
+
+	if v, ok := d.GetOk("contact_name"); ok {
+		if s, ok := v.(string); ok {
+			params.ContactName = &s
+		}
+	}
+	if v, ok := d.GetOk("contact_phone"); ok {
+		if s, ok := v.(string); ok {
+			params.ContactPhone = &s
+		}
+	}
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+
+	resp, err := client.Buildings.PostBuildings(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error creating building. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating building. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "building", d.Id())); diags != nil {
+		return diags
+	}
+
+	return resourceBuildingRead(ctx, d, meta)
+}
+
+func resourceBuildingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	building, diags := getBuilding(ctx, meta, id)
+	if diags != nil {
+		return diags
+	}
+
+	if building == nil {
+		if !d.IsNewResource() {
+			log.Printf("[WARN] building %s not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error building %s not found.", id)
+	}
+
+	d.Set("name", stringOrEmpty(building.Name))
+	d.Set("address", stringOrEmpty(building.Address))
+	d.Set("contact_name", stringOrEmpty(building.ContactName))
+	d.Set("notes", stringOrEmpty(building.Notes))
+	d.Set("building_id", id)
+
+	if v, ok := d.GetOk("custom_fields"); ok {
+		d.Set("custom_fields", flattenCustomFields(customFieldList(building.CustomFields), v.(map[string]interface{})))
+	}
+
+	return nil
+}
+
+func resourceBuildingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	// the name is the key, posting it again updates the building.
+	params := buildings.NewPostBuildingsParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+	params.Address = d.Get("address").(string)
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "address", "contact_name", "contact_phone", "notes")...))
+
+	if d.HasChange("contact_name") {
+		v := d.Get("contact_name").(string)
+		params.ContactName = &v
+	}
+	if d.HasChange("contact_phone") {
+		v := d.Get("contact_phone").(string)
+		params.ContactPhone = &v
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.Buildings.PostBuildings(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error updating building. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating building. %s", msg[0])
+	}
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "building", d.Id())); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceBuildingRead(ctx, d, meta)
+}
+
+func resourceBuildingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "building") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := buildings.NewDeleteBuildingsParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting building ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Buildings.DeleteBuildings(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error deleting building. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting building.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// getBuilding returns the building with the given ID, nil if there is none.
+// Device42 has no endpoint for a single building so all of them are listed.
+func getBuilding(ctx context.Context, meta interface{}, building_id string) (*models.BuildingsItems0, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := buildings.NewGetBuildingsParamsWithContext(ctx)
+
+	resp, err := client.Buildings.GetBuildings(params, nil)
+
+	if err != nil {
+		return nil, diag.Errorf("error retrieving buildings. %s", err)
+	}
+
+	for _, b := range resp.Payload.Buildings {
+		if b != nil && stringOrNumber(b.BuildingID) == building_id {
+			return b, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// getBuildingByName returns the building with the given name, nil if it
+// doesn't exist.
+func getBuildingByName(ctx context.Context, meta interface{}, name string) (*models.BuildingsItems0, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := buildings.NewGetBuildingsParamsWithContext(ctx)
+	params.SetName(&name)
+
+	resp, err := client.Buildings.GetBuildings(params, nil)
+
+	if err != nil {
+		return nil, diag.Errorf("error retrieving buildings. %s", err)
+	}
+
+	for _, b := range resp.Payload.Buildings {
+		if b != nil && stringOrEmpty(b.Name) == name {
+			return b, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceBuilding_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceBuildingConfig(name, "1 Example Street"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_building.test", "name", name),
+					resource.TestCheckResourceAttr("device42_building.test", "address", "1 Example Street"),
+				),
+			},
+			{
+				Config: testAccResourceBuildingConfig(name, "2 Example Street"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_building.test", "address", "2 Example Street"),
+				),
+			},
+			{
+				ResourceName:            "device42_building.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"contact_phone"},
+			},
+		},
+	})
+}
+
+func testAccResourceBuildingConfig(name, address string) string {
+	return fmt.Sprintf(`
+resource "device42_building" "test" {
+  name          = "%s"
+  address       = "%s"
+  contact_phone = "555-0100"
+}
+`, name, address)
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCustomField() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage custom fields.",
+
+		CreateContext: resourceCustomFieldCreate,
+		ReadContext:   resourceCustomFieldRead,
+		UpdateContext: resourceCustomFieldUpdate,
+		DeleteContext: resourceCustomFieldDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"object_type": {
+				Description:  "Object type the field is defined for.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(customFieldObjectTypes(), false),
+			},
+			"object_id": {
+				Description: "ID of the object the field is defined through. Device42 only creates custom fields by setting them on an object.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"key": {
+				Description: "Field name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Description: "Field type, e.g. `text`, `number`, `date`, `picklist` or `related_field`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "text",
+			},
+			"picklist": {
+				Description:      "Comma separated values of a `picklist` field.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffFakeListEqual,
+			},
+			"related_field_name": {
+				Description: "Name of the related field of a `related_field` field.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"value": {
+				Description: "Value of the field on the object.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"deletion_policy": deletionPolicySchema("custom field value"),
+		},
+	}
+}
+
+func resourceCustomFieldCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	object := d.Get("object_type").(string)
+	object_id := d.Get("object_id").(string)
+	key := d.Get("key").(string)
+
+	params := formParams{
+		"key":  key,
+		"type": d.Get("type").(string),
+	}
+
+	if v, ok := d.GetOk("value"); ok {
+		params["value"] = v.(string)
+	} else {
+		params["clear_value"] = "yes"
+	}
+	for k, p := range map[string]string{
+		"picklist":           "add_to_picklist",
+		"related_field_name": "related_field_name",
+		"notes":              "notes",
+	} {
+		if v, ok := d.GetOk(k); ok {
+			params[p] = v.(string)
+		}
+	}
+
+	if diags := setCustomField(ctx, meta, object, object_id, params); diags != nil {
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", object, object_id, key))
+
+	return resourceCustomFieldRead(ctx, d, meta)
+}
+
+func resourceCustomFieldRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	object, object_id, key, err := parseCustomFieldID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("object_type", object)
+	d.Set("object_id", object_id)
+	d.Set("key", key)
+
+	o, ok := customFieldObjects[object]
+	if !ok {
+		return diag.Errorf("error custom fields aren't supported on %s.", object)
+	}
+
+	fields, diags := o.fields(ctx, meta, object_id)
+	if diags != nil {
+		return diags
+	}
+
+	// field definitions can't be read, only the value on objects that
+	// return their custom fields.
+	if fields == nil {
+		return nil
+	}
+
+	v, _ := customFieldValue(fields, key)
+	d.Set("value", v)
+
+	return nil
+}
+
+func resourceCustomFieldUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	params := formParams{
+		"key":  d.Get("key").(string),
+		"type": d.Get("type").(string),
+	}
+
+	if d.HasChange("value") {
+		if v := d.Get("value").(string); v != "" {
+			params["value"] = v
+		} else {
+			params["clear_value"] = "yes"
+		}
+	}
+	// values can only be added to a picklist.
+	if d.HasChange("picklist") {
+		o, n := d.GetChange("picklist")
+		if v := listSubtract(n.(string), o.(string)); v != "" {
+			params["add_to_picklist"] = v
+		}
+	}
+	if d.HasChange("related_field_name") {
+		params["related_field_name"] = d.Get("related_field_name").(string)
+	}
+	if d.HasChange("notes") {
+		params["notes"] = d.Get("notes").(string)
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	if diags := setCustomField(ctx, meta, d.Get("object_type").(string), d.Get("object_id").(string), params); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceCustomFieldRead(ctx, d, meta)
+}
+
+func resourceCustomFieldDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "custom field value") {
+		d.SetId("")
+		return nil
+	}
+
+	// Device42 has no API to remove the field itself, only its value.
+	params := formParams{
+		"key":         d.Get("key").(string),
+		"clear_value": "yes",
+	}
+
+	if diags := setCustomField(ctx, meta, d.Get("object_type").(string), d.Get("object_id").(string), params); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// parseCustomFieldID splits an `object_type/object_id/key` ID.
+func parseCustomFieldID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("error invalid custom field ID %q, expected object_type/object_id/key", id)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
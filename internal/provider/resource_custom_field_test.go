@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poroping/libdevice42/client"
+)
+
+func TestParseCustomFieldID(t *testing.T) {
+	var tests = []struct {
+		id  string
+		out []string
+		err bool
+	}{
+		{"subnet/12/cost_centre", []string{"subnet", "12", "cost_centre"}, false},
+		{"device/3/owner/team", []string{"device", "3", "owner/team"}, false},
+		{"subnet/12", nil, true},
+		{"subnet//owner", nil, true},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing custom field ID, %v", i)
+		t.Run(testname, func(t *testing.T) {
+			object, id, key, err := parseCustomFieldID(tt.id)
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v, want error %v", err, tt.err)
+			}
+			if err == nil && !reflect.DeepEqual([]string{object, id, key}, tt.out) {
+				t.Errorf("got %v, want %v", []string{object, id, key}, tt.out)
+			}
+		})
+	}
+}
+
+func TestCustomFieldList(t *testing.T) {
+	fields := customFieldList([]interface{}{
+		map[string]interface{}{"key": "owner", "value": "netops", "notes": ""},
+		map[string]interface{}{"key": "cost_centre", "value": nil},
+		"garbage",
+	})
+
+	if len(fields) != 2 {
+		t.Fatalf("got %d fields, want 2", len(fields))
+	}
+	if v, ok := customFieldValue(fields, "owner"); !ok || v != "netops" {
+		t.Errorf("got %s, %v, want netops", v, ok)
+	}
+	if v, ok := customFieldValue(fields, "cost_centre"); !ok || v != "" {
+		t.Errorf("got %s, %v, want empty value", v, ok)
+	}
+	if got := customFieldList(nil); len(got) != 0 {
+		t.Errorf("got %v, want no fields", got)
+	}
+}
+
+func TestObjectPutCustomField(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		requests = append(requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, r.PostForm.Encode()))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"code": 0, "msg": ["custom key pair values added or updated"]}`)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	meta := &apiClient{
+		Device42: client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/",
+			Schemes:  []string{"http"},
+		}),
+	}
+
+	ctx := context.Background()
+
+	if err := objectPutCustomField(ctx, meta, "switch_vlan", "5")("owner", "ops", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := objectPutCustomField(ctx, meta, "device", "7")("owner", "", true); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"PUT /api/1.0/custom_fields/switch_vlan/ id=5&key=owner&value=ops",
+		"PUT /api/1.0/device/custom_field/ clear_value=yes&id=7&key=owner",
+	}
+	if !reflect.DeepEqual(requests, want) {
+		t.Errorf("requests %v, want %v", requests, want)
+	}
+}
+
+func TestAccResourceCustomField_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCustomFieldConfig(name, "netops"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_custom_field.test", "value", "netops"),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "custom_fields.tf_acc_owner", "netops"),
+				),
+			},
+			{
+				Config: testAccResourceCustomFieldConfig(name, "sre"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_custom_field.test", "value", "sre"),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "custom_fields.tf_acc_owner", "sre"),
+				),
+			},
+			{
+				ResourceName:            "device42_custom_field.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"type"},
+			},
+		},
+	})
+}
+
+func testAccResourceCustomFieldConfig(name, owner string) string {
+	return fmt.Sprintf(`
+resource "device42_ipam_subnet" "definer" {
+  name      = "%[1]s"
+  mask_bits = "29"
+  network   = "10.254.0.32"
+}
+
+resource "device42_custom_field" "test" {
+  object_type = "subnet"
+  object_id   = device42_ipam_subnet.definer.subnet_id
+  key         = "tf_acc_owner"
+  value       = "%[2]s"
+}
+
+resource "device42_ipam_subnet" "test" {
+  name      = "TF-ACC-TEST-CF"
+  mask_bits = "29"
+  network   = "10.254.0.24"
+
+  custom_fields = {
+    tf_acc_owner = "%[2]s"
+  }
+
+  depends_on = [device42_custom_field.test]
+}
+`, name, owner)
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client"
+	"github.com/poroping/libdevice42/client/customers"
+	"github.com/poroping/libdevice42/models"
+)
+
+func resourceCustomer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage customers.",
+
+		CreateContext: resourceCustomerCreate,
+		ReadContext:   resourceCustomerRead,
+		UpdateContext: resourceCustomerUpdate,
+		DeleteContext: resourceCustomerDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Customer name. Customers are keyed by name so changing it creates a new customer.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"contact_info": {
+				Description: "Contact info.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"custom_fields": customFieldsSchema(),
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"customer_id": {
+				Description: "Customer ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("customer"),
+		},
+	}
+}
+
+func resourceCustomerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := customers.NewPostCustomersParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+
+	// posting a customer updates the one with the same name.
+	existing, diags := getCustomer(ctx, meta, func(c *models.Customers) bool {
+		return stringOrEmpty(c.Name) == params.Name
+	})
+	if diags != nil {
+		return diags
+	}
+	if existing != nil {
+		return diag.Errorf("error customer %s already exists with ID %s, import it to manage it.", params.Name, stringOrNumber(existing.ID))
+	}
+
+	if v, ok := d.GetOk("contact_info"); ok {
+		if s, ok := v.(string); ok {
+			params.ContactInfo = &s
+		}
+	}
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+
+	resp, err := client.Customers.PostCustomers(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error creating customer. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating customer. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	if diags := updateCustomFields(d, customerPutCustomField(ctx, client, params.Name)); diags != nil {
+		return diags
+	}
+
+	return resourceCustomerRead(ctx, d, meta)
+}
+
+func resourceCustomerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	customer, diags := getCustomer(ctx, meta, func(c *models.Customers) bool {
+		return stringOrNumber(c.ID) == id
+	})
+	if diags != nil {
+		return diags
+	}
+
+	if customer == nil {
+		if !d.IsNewResource() {
+			log.Printf("[WARN] customer %s not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error customer %s not found.", id)
+	}
+
+	d.Set("name", stringOrEmpty(customer.Name))
+	d.Set("contact_info", stringOrEmpty(customer.ContactInfo))
+	d.Set("notes", stringOrEmpty(customer.Notes))
+	d.Set("customer_id", id)
+
+	if v, ok := d.GetOk("custom_fields"); ok {
+		d.Set("custom_fields", flattenCustomFields(customerCustomFields(customer), v.(map[string]interface{})))
+	}
+
+	return nil
+}
+
+func resourceCustomerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	// the name is the key, posting it again updates the customer.
+	params := customers.NewPostCustomersParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "contact_info", "notes")...))
+
+	if d.HasChange("contact_info") {
+		v := d.Get("contact_info").(string)
+		params.ContactInfo = &v
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	if d.HasChanges("contact_info", "notes") {
+		resp, err := client.Customers.PostCustomers(params, nil)
+
+		if err != nil {
+			return diag.Errorf("error updating customer. %s", err)
+		}
+
+		j_code := resp.Payload.Code.(json.Number)
+		code, _ := j_code.Int64()
+		msg := intList(resp.Payload.Msg.([]interface{}))
+		if code != 0 {
+			return diag.Errorf("error updating customer. %s", msg[0])
+		}
+	}
+
+	if diags := updateCustomFields(d, customerPutCustomField(ctx, client, params.Name)); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceCustomerRead(ctx, d, meta)
+}
+
+func resourceCustomerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "customer") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := customers.NewDeleteCustomersParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting customer ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Customers.DeleteCustomers(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error deleting customer. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting customer.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func customerPutCustomField(ctx context.Context, client *client.Device42, name string) putCustomFieldFunc {
+	return func(key, value string, clear bool) error {
+		params := customers.NewPutCustomFieldsParamsWithContext(ctx)
+		params.SetName(name)
+		params.SetKey(key)
+		if clear {
+			params.SetClearValue(yesNo(true))
+		} else {
+			params.SetValue(&value)
+		}
+
+		resp, err := client.Customers.PutCustomFields(params, nil)
+
+		if err != nil {
+			return err
+		}
+
+		if j_code, ok := resp.Payload.Code.(json.Number); ok {
+			if code, _ := j_code.Int64(); code != 0 {
+				return fmt.Errorf("%v", resp.Payload.Msg)
+			}
+		}
+
+		return nil
+	}
+}
+
+// getCustomer returns the first customer matching, nil if there is none.
+// Device42 has no endpoint for a single customer so all of them are listed.
+func getCustomer(ctx context.Context, meta interface{}, match func(*models.Customers) bool) (*models.Customers, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := customers.NewGetCustomersParamsWithContext(ctx)
+
+	resp, err := client.Customers.GetCustomers(params, nil)
+
+	if err != nil {
+		return nil, diag.Errorf("error retrieving customers. %s", err)
+	}
+
+	for _, c := range resp.Payload.Customers {
+		if c != nil && match(c) {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// customerID returns the ID of the customer with the given name.
+func customerID(ctx context.Context, meta interface{}, name string) (string, diag.Diagnostics) {
+	customer, diags := getCustomer(ctx, meta, func(c *models.Customers) bool {
+		return stringOrEmpty(c.Name) == name
+	})
+	if diags != nil {
+		return "", diags
+	}
+
+	if customer == nil {
+		return "", diag.Errorf("error customer %s not found.", name)
+	}
+
+	return stringOrNumber(customer.ID), nil
+}
+
+// customerName returns the name of the customer with the given ID, empty if
+// there is none.
+func customerName(ctx context.Context, meta interface{}, customer_id string) (string, diag.Diagnostics) {
+	if customer_id == "" {
+		return "", nil
+	}
+
+	customer, diags := getCustomer(ctx, meta, func(c *models.Customers) bool {
+		return stringOrNumber(c.ID) == customer_id
+	})
+	if diags != nil || customer == nil {
+		return "", diags
+	}
+
+	return stringOrEmpty(customer.Name), nil
+}
+
+func customerCustomFields(customer *models.Customers) []*customField {
+	fields := make([]*customField, 0, len(customer.CustomFields))
+	for _, f := range customer.CustomFields {
+		if f == nil {
+			continue
+		}
+		fields = append(fields, &customField{Key: f.Key, Notes: f.Notes, Value: f.Value})
+	}
+	return fields
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCustomer_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceCustomerConfig(name, "TF-ACC-TEST"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_customer.test", "name", name),
+					resource.TestCheckResourceAttr("device42_customer.test", "notes", "TF-ACC-TEST"),
+					resource.TestCheckResourceAttrPair("data.device42_customer.test", "customer_id", "device42_customer.test", "customer_id"),
+				),
+			},
+			{
+				Config: testAccResourceCustomerConfig(name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_customer.test", "notes", ""),
+				),
+			},
+			{
+				ResourceName:      "device42_customer.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccResourceCustomerConfig(name, "") + testAccResourceCustomerDuplicateConfig(),
+				ExpectError: regexp.MustCompile("already exists"),
+			},
+		},
+	})
+}
+
+func testAccResourceCustomerConfig(name, notes string) string {
+	return fmt.Sprintf(`
+resource "device42_customer" "test" {
+  name  = "%s"
+  notes = "%s"
+}
+
+data "device42_customer" "test" {
+  name = device42_customer.test.name
+
+  depends_on = [device42_customer.test]
+}
+`, name, notes)
+}
+
+func testAccResourceCustomerDuplicateConfig() string {
+	return `
+resource "device42_customer" "duplicate" {
+  name = device42_customer.test.name
+}
+`
+}
@@ -0,0 +1,415 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poroping/libdevice42/client/devices"
+	"github.com/poroping/libdevice42/models"
+)
+
+func resourceDevice() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage devices.",
+
+		CreateContext: resourceDeviceCreate,
+		ReadContext:   resourceDeviceRead,
+		UpdateContext: resourceDeviceUpdate,
+		DeleteContext: resourceDeviceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Device name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"type": {
+				Description:  "Device type. One of `physical`, `virtual` or `cluster`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "physical",
+				ValidateFunc: validation.StringInSlice([]string{"physical", "virtual", "cluster"}, false),
+			},
+			"asset_no": {
+				Description: "Asset number.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"custom_fields": customFieldsSchema(),
+			"customer": {
+				Description: "Customer name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"hardware": {
+				Description: "Hardware model name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"os": {
+				Description: "Operating system name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"serial_no": {
+				Description: "Serial number.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"service_level": {
+				Description: "Service level.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"tags": {
+				Description:      "Tags.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffFakeListEqual,
+			},
+			"virtual_host": {
+				Description: "Name of the virtual host, for `virtual` devices.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"device_id": {
+				Description: "Device ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("device"),
+		},
+	}
+}
+
+func resourceDeviceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewPostDeviceParamsWithContext(ctx)
+
+	name := d.Get("name").(string)
+	params.Name = &name
+
+	// posting a device updates the one with the same name.
+	existing, diags := deviceIDByName(ctx, meta, name)
+	if diags != nil {
+		return diags
+	}
+	if existing != "" {
+		return diag.Errorf("error device %s already exists with ID %s, import it to manage it.", name, existing)
+	}
+
+	if v, ok := d.GetOk("type"); ok {
+		if s, ok := v.(string); ok {
+			params.Type = &s
+		}
+	}
+	if v, ok := d.GetOk("asset_no"); ok {
+		if s, ok := v.(string); ok {
+			params.AssetNo = &s
+		}
+	}
+	if v, ok := d.GetOk("customer"); ok {
+		if s, ok := v.(string); ok {
+			params.Customer = &s
+		}
+	}
+	if v, ok := d.GetOk("hardware"); ok {
+		if s, ok := v.(string); ok {
+			params.Hardware = &s
+		}
+	}
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+	if v, ok := d.GetOk("os"); ok {
+		if s, ok := v.(string); ok {
+			params.Os = &s
+		}
+	}
+	if v, ok := d.GetOk("serial_no"); ok {
+		if s, ok := v.(string); ok {
+			params.SerialNo = &s
+		}
+	}
+	if v, ok := d.GetOk("service_level"); ok {
+		if s, ok := v.(string); ok {
+			params.ServiceLevel = &s
+		}
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		if s, ok := v.(string); ok {
+			params.Tags = &s
+		}
+	}
+	if v, ok := d.GetOk("virtual_host"); ok {
+		if s, ok := v.(string); ok {
+			params.VirtualHost = &s
+		}
+	}
+
+	resp, err := client.Devices.PostDevice(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error creating device. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating device. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "device", d.Id())); diags != nil {
+		return diags
+	}
+
+	return resourceDeviceRead(ctx, d, meta)
+}
+
+func resourceDeviceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewGetDevicesIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting device ID. %s", err)
+	}
+	params.SetDeviceID(int64(i))
+
+	resp, err := client.Devices.GetDevicesID(params, nil)
+
+	if _, ok := err.(*devices.GetDevicesIDNotFound); ok && !d.IsNewResource() {
+		log.Printf("[WARN] device %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error reading device. %s", err)
+	}
+
+	setDevice(d, resp.Payload)
+
+	if v, ok := d.GetOk("custom_fields"); ok {
+		d.Set("custom_fields", flattenCustomFields(deviceCustomFields(resp.Payload.CustomFields), v.(map[string]interface{})))
+	}
+
+	return nil
+}
+
+func resourceDeviceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewPutDeviceParamsWithContext(ctx)
+	id := d.Id()
+	params.SetDeviceID(&id)
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "asset_no", "customer", "hardware", "notes", "os", "serial_no")...))
+
+	if d.HasChange("name") {
+		v := d.Get("name").(string)
+		params.NewName = &v
+	}
+	if d.HasChange("type") {
+		v := d.Get("type").(string)
+		params.Type = &v
+	}
+	if d.HasChange("asset_no") {
+		v := d.Get("asset_no").(string)
+		params.AssetNo = &v
+	}
+	if d.HasChange("customer") {
+		v := d.Get("customer").(string)
+		params.Customer = &v
+	}
+	if d.HasChange("hardware") {
+		v := d.Get("hardware").(string)
+		params.Hardware = &v
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+	if d.HasChange("os") {
+		v := d.Get("os").(string)
+		params.Os = &v
+	}
+	if d.HasChange("serial_no") {
+		v := d.Get("serial_no").(string)
+		params.SerialNo = &v
+	}
+	if d.HasChange("service_level") {
+		v := d.Get("service_level").(string)
+		params.ServiceLevel = &v
+	}
+	if d.HasChange("tags") {
+		// tags sent on update are added, the ones gone from configuration
+		// have to be removed explicitly.
+		o, n := d.GetChange("tags")
+		if v := n.(string); v != "" {
+			params.Tags = &v
+		}
+		if v := listSubtract(o.(string), n.(string)); v != "" {
+			params.TagsRemove = &v
+		}
+	}
+	if d.HasChange("virtual_host") {
+		if v := d.Get("virtual_host").(string); v != "" {
+			params.VirtualHost = &v
+		} else {
+			params.VirtualHostClear = yesNo(true)
+		}
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.Devices.PutDevice(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error updating device. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating device. %s", msg[0])
+	}
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "device", id)); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceDeviceRead(ctx, d, meta)
+}
+
+func resourceDeviceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "device") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewDeleteDevicesIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting device ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Devices.DeleteDevicesID(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error deleting device. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting device.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func setDevice(d *schema.ResourceData, resp *models.DevicesAll) {
+	if v, ok := resp.Name.(string); ok {
+		d.Set("name", v)
+	}
+	if v, ok := resp.Type.(string); ok {
+		d.Set("type", v)
+	}
+	d.Set("asset_no", stringOrEmpty(resp.AssetNo))
+	d.Set("customer", stringOrEmpty(resp.Customer))
+	d.Set("hardware", stringOrEmpty(resp.HwModel))
+	d.Set("notes", stringOrEmpty(resp.Notes))
+	d.Set("os", stringOrEmpty(resp.Os))
+	d.Set("serial_no", stringOrEmpty(resp.SerialNo))
+	d.Set("service_level", stringOrEmpty(resp.ServiceLevel))
+	d.Set("tags", flattenTagList(resp.Tags))
+	d.Set("virtual_host", stringOrEmpty(resp.VirtualHostName))
+	if resp.DeviceID != nil {
+		d.Set("device_id", fmt.Sprint(resp.DeviceID))
+	} else if resp.ID != nil {
+		d.Set("device_id", fmt.Sprint(resp.ID))
+	}
+}
+
+// deviceName returns the name of the device with the given ID.
+func deviceName(ctx context.Context, meta interface{}, device_id string) (string, diag.Diagnostics) {
+	device, diags := getDevice(ctx, meta, device_id)
+	if diags != nil {
+		return "", diags
+	}
+
+	name, ok := device.Name.(string)
+	if !ok || name == "" {
+		return "", diag.Errorf("error device %s has no name.", device_id)
+	}
+
+	return name, nil
+}
+
+// deviceCustomFields converts the device custom fields for flattenCustomFields.
+func deviceCustomFields(fields models.DeviceAllCustomField) []*customField {
+	l := make([]*customField, 0, len(fields))
+	for _, f := range fields {
+		if f == nil {
+			continue
+		}
+		l = append(l, &customField{Key: f.Key, Notes: f.Notes, Value: f.Value})
+	}
+	return l
+}
+
+// deviceIDByName returns the ID of the device with the given name, empty if
+// there is none.
+func deviceIDByName(ctx context.Context, meta interface{}, name string) (string, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewGetDevicesParamsWithContext(ctx)
+	params.SetName(&name)
+
+	resp, err := client.Devices.GetDevices(params, nil)
+
+	if err != nil {
+		return "", diag.Errorf("error retrieving devices. %s", err)
+	}
+
+	for _, device := range resp.Payload.Devices {
+		if device != nil && stringOrEmpty(device.Name) == name {
+			return stringOrNumber(device.DeviceID), nil
+		}
+	}
+
+	return "", nil
+}
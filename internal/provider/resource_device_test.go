@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceDevice_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDeviceConfig(name, `notes = "TF-ACC-TEST"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_device.test", "name", name),
+					resource.TestCheckResourceAttr("device42_device.test", "type", "virtual"),
+					resource.TestCheckResourceAttr("device42_device.test", "notes", "TF-ACC-TEST"),
+				),
+			},
+			{
+				Config: testAccResourceDeviceConfig(name, `notes = ""`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_device.test", "notes", ""),
+				),
+			},
+			{
+				ResourceName:      "device42_device.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceDeviceConfig(name, extra string) string {
+	return fmt.Sprintf(`
+resource "device42_device" "test" {
+  name = "%s"
+  type = "virtual"
+  %s
+}
+`, name, extra)
+}
@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poroping/libdevice42/client"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+var dnsRecordTypes = []string{"A", "AAAA", "CNAME", "MX", "NS", "PTR", "SOA", "SRV", "TXT"}
+
+func resourceDNSRecord() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage DNS records.",
+
+		CreateContext: resourceDNSRecordCreate,
+		ReadContext:   resourceDNSRecordRead,
+		UpdateContext: resourceDNSRecordUpdate,
+		DeleteContext: resourceDNSRecordDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Description: "Name of the DNS zone the record is in.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Description:  "Record type, e.g. `A`, `AAAA`, `CNAME` or `PTR`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(dnsRecordTypes, false),
+			},
+			"name": {
+				Description: "Record name within the zone, `@` for the zone itself.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "@",
+			},
+			"content": {
+				Description: "Record content, e.g. the IP address of an `A` record.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"nameserver": {
+				Description: "Name server of the zone, required to pick the view when zones with the same name exist on several name servers.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"prio": {
+				Description: "Priority, for `MX` records.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"ttl": {
+				Description: "TTL in seconds.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"dns_record_id": {
+				Description: "DNS record ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("DNS record"),
+		},
+	}
+}
+
+func resourceDNSRecordCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	id, err := createDNSRecord(ctx, client, dnsRecordFromConfig(d))
+
+	if err != nil {
+		return diag.Errorf("error creating DNS record. %s", err)
+	}
+
+	d.SetId(id)
+
+	return resourceDNSRecordRead(ctx, d, meta)
+}
+
+func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMDNSRecordsParamsWithContext(ctx)
+	id := d.Id()
+
+	// records can only be listed, narrow them down with what is known.
+	if v, ok := d.GetOk("zone"); ok {
+		s := v.(string)
+		params.SetDomain(&s)
+	}
+	if v, ok := d.GetOk("type"); ok {
+		s := v.(string)
+		params.SetType(&s)
+	}
+
+	resp, err := client.IPam.GetIPAMDNSRecords(params)
+
+	if err != nil {
+		return diag.Errorf("error retrieving DNS records. %s", err)
+	}
+
+	for _, r := range resp.Payload.Records {
+		if r == nil || stringOrNumber(r.ID) != id {
+			continue
+		}
+
+		d.Set("zone", stringOrEmpty(r.DNSZone))
+		d.Set("type", stringOrEmpty(r.Type))
+		d.Set("name", stringOrEmpty(r.Name))
+		d.Set("content", stringOrEmpty(r.Content))
+		d.Set("nameserver", stringOrEmpty(r.Nameserver))
+		d.Set("prio", intOrZero(r.Prio))
+		d.Set("ttl", intOrZero(r.TTL))
+		d.Set("dns_record_id", id)
+
+		return nil
+	}
+
+	if !d.IsNewResource() {
+		log.Printf("[WARN] DNS record %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	return diag.Errorf("error DNS record %s not found.", id)
+}
+
+func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	// a removed prio or ttl isn't posted as zero, it has to be cleared.
+	cleared := make([]string, 0)
+	for _, k := range []string{"prio", "ttl"} {
+		if d.HasChange(k) && d.Get(k).(int) == 0 {
+			cleared = append(cleared, k)
+		}
+	}
+
+	// zone, type, name and content are the key, posting them again updates
+	// the record.
+	if _, err := postDNSRecord(withClearedFormParams(ctx, cleared...), client, dnsRecordFromConfig(d)); err != nil {
+		return diag.Errorf("error updating DNS record. %s", err)
+	}
+
+	return resourceDNSRecordRead(ctx, d, meta)
+}
+
+func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "DNS record") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	if err := deleteDNSRecord(ctx, client, d.Id()); err != nil {
+		return diag.Errorf("error deleting DNS record. %s", err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// dnsRecord is a DNS record to post.
+type dnsRecord struct {
+	zone       string
+	nameserver string
+	recordType string
+	name       string
+	content    string
+	prio       int
+	ttl        int
+}
+
+func dnsRecordFromConfig(d *schema.ResourceData) dnsRecord {
+	return dnsRecord{
+		zone:       d.Get("zone").(string),
+		nameserver: d.Get("nameserver").(string),
+		recordType: d.Get("type").(string),
+		name:       d.Get("name").(string),
+		content:    d.Get("content").(string),
+		prio:       d.Get("prio").(int),
+		ttl:        d.Get("ttl").(int),
+	}
+}
+
+// postDNSRecord creates or updates a DNS record, returning its ID.
+func postDNSRecord(ctx context.Context, client *client.Device42, r dnsRecord) (string, error) {
+	params := ipam.NewPostIPAMDNSRecordsParamsWithContext(ctx)
+	params.Domain = r.zone
+	params.Type = r.recordType
+	params.Name = &r.name
+	params.Content = &r.content
+
+	if r.nameserver != "" {
+		params.Nameserver = &r.nameserver
+	}
+	if r.prio != 0 {
+		s := strconv.Itoa(r.prio)
+		params.Prio = &s
+	}
+	if r.ttl != 0 {
+		s := strconv.Itoa(r.ttl)
+		params.TTL = &s
+	}
+
+	resp, err := client.IPam.PostIPAMDNSRecords(params)
+
+	if err != nil {
+		return "", err
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return "", fmt.Errorf("%s", msg[0])
+	}
+
+	return string(msg[1]), nil
+}
+
+// createDNSRecord posts a DNS record that doesn't exist yet. Posting updates
+// the record with the same zone, type, name and content, an existing one is an
+// error rather than taken over.
+func createDNSRecord(ctx context.Context, client *client.Device42, r dnsRecord) (string, error) {
+	params := ipam.NewGetIPAMDNSRecordsParamsWithContext(ctx)
+	params.SetDomain(&r.zone)
+	params.SetType(&r.recordType)
+	params.SetName(&r.name)
+
+	resp, err := client.IPam.GetIPAMDNSRecords(params)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range resp.Payload.Records {
+		if e == nil || stringOrEmpty(e.Name) != r.name || stringOrEmpty(e.Content) != r.content {
+			continue
+		}
+		if r.nameserver != "" && stringOrEmpty(e.Nameserver) != r.nameserver {
+			continue
+		}
+		return "", fmt.Errorf("%s record %s already exists with ID %s, import it to manage it", r.recordType, r.name, stringOrNumber(e.ID))
+	}
+
+	return postDNSRecord(ctx, client, r)
+}
+
+// deleteDNSRecord deletes a DNS record, one that is already gone is fine.
+func deleteDNSRecord(ctx context.Context, client *client.Device42, id string) error {
+	params := ipam.NewDeleteIPAMDNSRecordsParamsWithContext(ctx)
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return err
+	}
+	params.SetID(i)
+
+	resp, err := client.IPam.DeleteIPAMDNSRecords(params)
+
+	if _, ok := err.(*ipam.DeleteIPAMDNSRecordsNotFound); ok {
+		log.Printf("[WARN] DNS record %s already deleted", id)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return fmt.Errorf("record %s not deleted", id)
+	}
+
+	return nil
+}
+
+// forwardRecordType returns `A` or `AAAA` for the address.
+func forwardRecordType(ip string) (string, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "", fmt.Errorf("invalid IP address %s", ip)
+	}
+	if addr.To4() != nil {
+		return "A", nil
+	}
+	return "AAAA", nil
+}
+
+// recordFQDN returns the fully qualified name of a record relative to a zone,
+// the zone itself for `@`.
+func recordFQDN(name, zone string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	if name == "@" || name == "" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// reverseRecordName returns the name of the PTR record for the address
+// relative to the reverse zone, `@` if it is the zone itself.
+func reverseRecordName(ip, zone string) (string, error) {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return "", fmt.Errorf("invalid IP address %s", ip)
+	}
+
+	var labels []string
+	if v4 := addr.To4(); v4 != nil {
+		for i := len(v4) - 1; i >= 0; i-- {
+			labels = append(labels, strconv.Itoa(int(v4[i])))
+		}
+		labels = append(labels, "in-addr", "arpa")
+	} else {
+		const hex = "0123456789abcdef"
+		for i := len(addr) - 1; i >= 0; i-- {
+			labels = append(labels, string(hex[addr[i]&0xf]), string(hex[addr[i]>>4]))
+		}
+		labels = append(labels, "ip6", "arpa")
+	}
+
+	name := strings.Join(labels, ".")
+	zone = strings.TrimSuffix(strings.ToLower(zone), ".")
+
+	if name == zone {
+		return "@", nil
+	}
+	if !strings.HasSuffix(name, "."+zone) {
+		return "", fmt.Errorf("%s is not in reverse zone %s", ip, zone)
+	}
+
+	return strings.TrimSuffix(name, "."+zone), nil
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poroping/libdevice42/client"
+)
+
+func TestReverseRecordName(t *testing.T) {
+	var tests = []struct {
+		ip, zone string
+		name     string
+		err      bool
+	}{
+		{"10.0.1.2", "0.10.in-addr.arpa", "2.1", false},
+		{"10.0.1.2", "1.0.10.in-addr.arpa.", "2", false},
+		{"10.0.1.2", "2.1.0.10.in-addr.arpa", "@", false},
+		{"10.0.1.2", "1.10.in-addr.arpa", "", true},
+		{"2001:db8::1", "8.b.d.0.1.0.0.2.ip6.arpa", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0", false},
+		{"not-an-ip", "10.in-addr.arpa", "", true},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing reverse record name, %v", i)
+		t.Run(testname, func(t *testing.T) {
+			name, err := reverseRecordName(tt.ip, tt.zone)
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v, want error %v", err, tt.err)
+			}
+			if name != tt.name {
+				t.Errorf("got %s, want %s", name, tt.name)
+			}
+		})
+	}
+}
+
+func TestRecordFQDN(t *testing.T) {
+	for _, tt := range []struct {
+		name, zone string
+		want       string
+	}{
+		{"web", "example.com", "web.example.com"},
+		{"web", "example.com.", "web.example.com"},
+		{"@", "example.com.", "example.com"},
+		{"", "example.com", "example.com"},
+	} {
+		if got := recordFQDN(tt.name, tt.zone); got != tt.want {
+			t.Errorf("recordFQDN(%q, %q) = %s, want %s", tt.name, tt.zone, got, tt.want)
+		}
+	}
+}
+
+func TestPostDNSRecordCleared(t *testing.T) {
+	var form url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"code": 0, "msg": ["dns record added or updated", 3, "mail"]}`)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c := client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/",
+		Schemes:  []string{"http"},
+	})
+	c.SetTransport(&clearingTransport{c.Transport})
+
+	r := dnsRecord{zone: "example.com", recordType: "MX", name: "@", content: "mail.example.com"}
+
+	if _, err := postDNSRecord(withClearedFormParams(context.Background(), "prio"), c, r); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := form["prio"]; !ok || v[0] != "" {
+		t.Errorf("prio = %v, want it sent empty", v)
+	}
+
+	r.prio = 10
+	if _, err := postDNSRecord(context.Background(), c, r); err != nil {
+		t.Fatal(err)
+	}
+	if got := form.Get("prio"); got != "10" {
+		t.Errorf("prio = %q, want 10", got)
+	}
+}
+
+func TestForwardRecordType(t *testing.T) {
+	for ip, want := range map[string]string{
+		"10.0.0.1":    "A",
+		"2001:db8::1": "AAAA",
+	} {
+		if got, err := forwardRecordType(ip); err != nil || got != want {
+			t.Errorf("forwardRecordType(%s) = %s, %v, want %s", ip, got, err, want)
+		}
+	}
+	if _, err := forwardRecordType("not-an-ip"); err == nil {
+		t.Errorf("expected error for invalid address")
+	}
+}
+
+func TestAccResourceDNSRecord_basic(t *testing.T) {
+	zone := fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceDNSRecordConfig(zone, 300),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_dns_record.test", "zone", zone),
+					resource.TestCheckResourceAttr("device42_dns_record.test", "content", "192.0.2.10"),
+					resource.TestCheckResourceAttr("device42_dns_record.test", "ttl", "300"),
+				),
+			},
+			{
+				Config: testAccResourceDNSRecordConfig(zone, 600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_dns_record.test", "ttl", "600"),
+				),
+			},
+			{
+				ResourceName:      "device42_dns_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "device42_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceDNSRecordConfig(zone string, ttl int) string {
+	return fmt.Sprintf(`
+resource "device42_dns_zone" "test" {
+  name       = "%s"
+  nameserver = "ns1.example.com"
+}
+
+resource "device42_dns_record" "test" {
+  zone       = device42_dns_zone.test.name
+  nameserver = device42_dns_zone.test.nameserver
+  type       = "A"
+  name       = "www"
+  content    = "192.0.2.10"
+  ttl        = %d
+}
+`, zone, ttl)
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+func resourceDNSZone() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage DNS zones.",
+
+		CreateContext: resourceDNSZoneCreate,
+		ReadContext:   resourceDNSZoneRead,
+		UpdateContext: resourceDNSZoneUpdate,
+		DeleteContext: resourceDNSZoneDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Zone name, e.g. `example.com` or `0.10.in-addr.arpa`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"nameserver": {
+				Description: "IP address or hostname of the name server. Zones with the same name on different name servers are separate views.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tags": {
+				Description:      "Tags.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffFakeListEqual,
+			},
+			"vrf_group": {
+				Description: "VRF group name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"dns_zone_id": {
+				Description: "DNS zone ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("DNS zone"),
+		},
+	}
+}
+
+func resourceDNSZoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewPostIPAMDNSZonesParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+	params.Nameserver = d.Get("nameserver").(string)
+
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		if s, ok := v.(string); ok {
+			params.Tags = &s
+		}
+	}
+	if v, ok := d.GetOk("vrf_group"); ok {
+		if s, ok := v.(string); ok {
+			params.VrfGroup = &s
+		}
+	}
+
+	resp, err := client.IPam.PostIPAMDNSZones(params)
+
+	if err != nil {
+		return diag.Errorf("error creating DNS zone. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating DNS zone. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	return resourceDNSZoneRead(ctx, d, meta)
+}
+
+func resourceDNSZoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	id := d.Id()
+
+	zones, err := getDNSZones(ctx, client, queryParams{})
+
+	if err != nil {
+		return diag.Errorf("error retrieving DNS zones. %s", err)
+	}
+
+	var zone *dnsZone
+	for _, z := range zones {
+		if z != nil && stringOrNumber(z.ID) == id {
+			zone = z
+			break
+		}
+	}
+
+	if zone == nil {
+		if !d.IsNewResource() {
+			log.Printf("[WARN] DNS zone %s not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error DNS zone %s not found.", id)
+	}
+
+	d.Set("name", stringOrEmpty(zone.Name))
+	d.Set("nameserver", stringOrEmpty(zone.Nameserver))
+	d.Set("notes", stringOrEmpty(zone.Notes))
+	d.Set("tags", flattenTagList(zone.Tags))
+	d.Set("vrf_group", stringOrEmpty(zone.VrfGroup))
+	d.Set("dns_zone_id", id)
+
+	return nil
+}
+
+func resourceDNSZoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	// the name and name server are the key, posting them again updates the
+	// zone.
+	params := ipam.NewPostIPAMDNSZonesParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+	params.Nameserver = d.Get("nameserver").(string)
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "notes", "vrf_group")...))
+
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+	if d.HasChange("tags") {
+		o, n := d.GetChange("tags")
+		if v := n.(string); v != "" {
+			params.Tags = &v
+		}
+		if v := listSubtract(o.(string), n.(string)); v != "" {
+			params.TagsRemove = &v
+		}
+	}
+	if d.HasChange("vrf_group") {
+		v := d.Get("vrf_group").(string)
+		params.VrfGroup = &v
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.IPam.PostIPAMDNSZones(params)
+
+	if err != nil {
+		return diag.Errorf("error updating DNS zone. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating DNS zone. %s", msg[0])
+	}
+
+	d.Partial(false)
+
+	return resourceDNSZoneRead(ctx, d, meta)
+}
+
+func resourceDNSZoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "DNS zone") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	deleted, err := deleteDNSZone(ctx, client, d.Id())
+
+	if err != nil {
+		return diag.Errorf("error deleting DNS zone. %s", err)
+	}
+
+	if !deleted {
+		return diag.Errorf("error deleting DNS zone.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
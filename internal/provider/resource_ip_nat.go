@@ -0,0 +1,352 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+func resourceIPNat() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage NAT mappings between IP addresses.",
+
+		CreateContext: resourceIPNatCreate,
+		ReadContext:   resourceIPNatRead,
+		UpdateContext: resourceIPNatUpdate,
+		DeleteContext: resourceIPNatDelete,
+
+		CustomizeDiff: resourceIPNatCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIPNatImport,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the NAT entry.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"ip_address_from": {
+				Description: "External IP address.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"ip_address_to": {
+				Description: "Internal IP address.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"ip_address_from_end": {
+				Description: "Last external IP address, to map a range of addresses.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ip_address_to_end": {
+				Description: "Last internal IP address, to map a range of addresses.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"protocol": {
+				Description: "Transport protocol, e.g. `tcp`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"source_port_start": {
+				Description:  "External port, or the first of a range.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"source_port_end": {
+				Description:  "Last external port of a range.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"target_port_start": {
+				Description:  "Internal port, or the first of a range.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"target_port_end": {
+				Description:  "Last internal port of a range.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"two_way_relation": {
+				Description: "Also translate outbound traffic from the internal to the external address, not just inbound.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"vrf_group_from": {
+				Description: "VRF group name of the external address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"vrf_group_to": {
+				Description: "VRF group name of the internal address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ip_nat_id": {
+				Description: "NAT entry ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"imported": {
+				Description: "Whether the entry was imported and the next apply records the ranges, protocol, ports and VRF groups from configuration.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("NAT entry"),
+		},
+	}
+}
+
+func resourceIPNatCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewPostIPAMIpnatParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+	params.IPAddressFrom = d.Get("ip_address_from").(string)
+	params.IPAddressTo = d.Get("ip_address_to").(string)
+	params.TwoWayRelation = yesNo(d.Get("two_way_relation").(bool))
+
+	for k, p := range map[string]**string{
+		"ip_address_from_end": &params.IPAddressFromEnd,
+		"ip_address_to_end":   &params.IPAddressToEnd,
+		"notes":               &params.Notes,
+		"protocol":            &params.Protocol,
+		"vrf_group_from":      &params.VrfGroupFrom,
+		"vrf_group_to":        &params.VrfGroupTo,
+	} {
+		if v, ok := d.GetOk(k); ok {
+			s := v.(string)
+			*p = &s
+		}
+	}
+	for k, p := range map[string]**string{
+		"source_port_start": &params.SourcePortStart,
+		"source_port_end":   &params.SourcePortEnd,
+		"target_port_start": &params.TargetPortStart,
+		"target_port_end":   &params.TargetPortEnd,
+	} {
+		if v, ok := d.GetOk(k); ok {
+			s := stringOrNumber(v)
+			*p = &s
+		}
+	}
+
+	resp, err := client.IPam.PostIPAMIpnat(params)
+
+	if err != nil {
+		return diag.Errorf("error creating NAT entry. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating NAT entry. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+	d.Set("imported", false)
+
+	return resourceIPNatRead(ctx, d, meta)
+}
+
+func resourceIPNatRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMIpnatParamsWithContext(ctx)
+	id := d.Id()
+
+	// Device42 has no endpoint for a single NAT entry so all of them are
+	// listed. Ranges, protocol, ports and VRF groups aren't returned and are
+	// kept as configured.
+	resp, err := client.IPam.GetIPAMIpnat(params)
+
+	if err != nil {
+		return diag.Errorf("error retrieving NAT entries. %s", err)
+	}
+
+	var nat *ipam.IpnatsItems0
+	for _, v := range resp.Payload.Ipnats {
+		if v != nil && stringOrNumber(v.ID) == id {
+			nat = v
+			break
+		}
+	}
+
+	if nat == nil {
+		if !d.IsNewResource() {
+			log.Printf("[WARN] NAT entry %s not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error NAT entry %s not found.", id)
+	}
+
+	d.Set("name", stringOrEmpty(nat.Name))
+	d.Set("ip_address_from", ipNatAddress(nat.IPAddressFrom))
+	d.Set("ip_address_to", ipNatAddress(nat.IPAddressTo))
+	d.Set("notes", stringOrEmpty(nat.Notes))
+	d.Set("ip_nat_id", id)
+	if b, ok := parseYesNo(nat.TwoWayRelation); ok {
+		d.Set("two_way_relation", b)
+	}
+
+	return nil
+}
+
+func resourceIPNatUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewPutIPAMIpnatParamsWithContext(ctx)
+	params.SetID(d.Id())
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "notes")...))
+
+	if d.HasChange("name") {
+		v := d.Get("name").(string)
+		params.Name = &v
+	}
+	if d.HasChange("ip_address_from") {
+		v := d.Get("ip_address_from").(string)
+		params.IPAddressFrom = &v
+	}
+	if d.HasChange("ip_address_to") {
+		v := d.Get("ip_address_to").(string)
+		params.IPAddressTo = &v
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+	if d.HasChange("two_way_relation") {
+		params.TwoWayRelation = yesNo(d.Get("two_way_relation").(bool))
+	}
+
+	// the create-only attributes are only recorded, see
+	// resourceIPNatCustomizeDiff.
+	if !d.HasChanges("name", "ip_address_from", "ip_address_to", "notes", "two_way_relation") {
+		return resourceIPNatRead(ctx, d, meta)
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.IPam.PutIPAMIpnat(params)
+
+	if err != nil {
+		return diag.Errorf("error updating NAT entry. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating NAT entry. %s", msg[0])
+	}
+
+	d.Partial(false)
+
+	return resourceIPNatRead(ctx, d, meta)
+}
+
+func resourceIPNatDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "NAT entry") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewDeleteIPAMIpnatParamsWithContext(ctx)
+	params.SetID(d.Id())
+
+	resp, err := client.IPam.DeleteIPAMIpnat(params)
+
+	if err != nil {
+		return diag.Errorf("error deleting NAT entry. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting NAT entry.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// ipNatAddress returns the address of a NAT entry side, which Device42
+// returns either as the plain address or as the IP object.
+func ipNatAddress(v interface{}) string {
+	if m, ok := v.(map[string]interface{}); ok {
+		return stringOrEmpty(m["ip"])
+	}
+	return stringOrEmpty(v)
+}
+
+// ipNatCreateOnly are the attributes Device42 only accepts on create and never
+// returns.
+var ipNatCreateOnly = []string{
+	"ip_address_from_end",
+	"ip_address_to_end",
+	"protocol",
+	"source_port_start",
+	"source_port_end",
+	"target_port_start",
+	"target_port_end",
+	"vrf_group_from",
+	"vrf_group_to",
+}
+
+// resourceIPNatImport marks the entry as imported, its create-only
+// attributes are unknown until the next apply records them.
+func resourceIPNatImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("imported", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceIPNatCustomizeDiff replaces the NAT entry when a create-only
+// attribute changes. The first plan after import records the configured
+// values without replacing it.
+func resourceIPNatCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.Get("imported").(bool) {
+		return d.SetNew("imported", false)
+	}
+
+	for _, k := range ipNatCreateOnly {
+		if !d.HasChange(k) {
+			continue
+		}
+		if err := d.ForceNew(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
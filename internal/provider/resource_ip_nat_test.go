@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestIPNatAddress(t *testing.T) {
+	for _, tt := range []struct {
+		v    interface{}
+		want string
+	}{
+		{"203.0.113.1", "203.0.113.1"},
+		{map[string]interface{}{"ip": "10.0.0.1", "id": 12}, "10.0.0.1"},
+		{nil, ""},
+	} {
+		if got := ipNatAddress(tt.v); got != tt.want {
+			t.Errorf("ipNatAddress(%v) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestIPNatCreateOnlyDiff(t *testing.T) {
+	config := map[string]interface{}{
+		"name":              "web",
+		"ip_address_from":   "203.0.113.1",
+		"ip_address_to":     "10.0.0.1",
+		"protocol":          "tcp",
+		"source_port_start": 443,
+		"target_port_start": 8443,
+	}
+	entry := map[string]string{
+		"id":               "12",
+		"name":             "web",
+		"ip_address_from":  "203.0.113.1",
+		"ip_address_to":    "10.0.0.1",
+		"two_way_relation": "false",
+		"deletion_policy":  "delete",
+	}
+	imported := map[string]string{"imported": "true"}
+	added := map[string]string{"imported": "false"}
+	created := map[string]string{
+		"imported":          "false",
+		"protocol":          "tcp",
+		"source_port_start": "443",
+		"target_port_start": "8443",
+	}
+	for k, v := range entry {
+		imported[k] = v
+		added[k] = v
+		created[k] = v
+	}
+
+	var tests = []struct {
+		name        string
+		attributes  map[string]string
+		config      map[string]interface{}
+		requiresNew bool
+	}{
+		{"imported", imported, config, false},
+		{"attributes added later", added, config, true},
+		{"unchanged", created, config, false},
+		{"port changed", created, map[string]interface{}{
+			"name":              "web",
+			"ip_address_from":   "203.0.113.1",
+			"ip_address_to":     "10.0.0.1",
+			"protocol":          "tcp",
+			"source_port_start": 443,
+			"target_port_start": 9443,
+		}, true},
+		{"protocol removed", created, map[string]interface{}{
+			"name":            "web",
+			"ip_address_from": "203.0.113.1",
+			"ip_address_to":   "10.0.0.1",
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{ID: "12", Attributes: tt.attributes}
+			diff, err := resourceIPNat().Diff(context.Background(), state, terraform.NewResourceConfigRaw(tt.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := diff != nil && diff.RequiresNew(); got != tt.requiresNew {
+				t.Errorf("RequiresNew() = %t, want %t: %v", got, tt.requiresNew, diff)
+			}
+			if tt.attributes["imported"] == "true" && (diff == nil || diff.Attributes["imported"] == nil || diff.Attributes["imported"].New != "false") {
+				t.Errorf("the plan after import doesn't clear imported: %v", diff)
+			}
+		})
+	}
+}
+
+func TestAccResourceIPNat_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIPNatConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ip_nat.test", "ip_address_from", "10.254.0.17"),
+					resource.TestCheckResourceAttr("device42_ip_nat.test", "ip_address_to", "10.254.0.18"),
+					resource.TestCheckResourceAttr("device42_ip_nat.test", "two_way_relation", "false"),
+				),
+			},
+			{
+				Config: testAccResourceIPNatConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ip_nat.test", "two_way_relation", "true"),
+				),
+			},
+			// the create-only attributes aren't returned by Device42, the
+			// import plan records them, see TestIPNatCreateOnlyDiff.
+			{
+				ResourceName:            "device42_ip_nat.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"imported", "protocol", "source_port_start", "target_port_start"},
+			},
+		},
+	})
+}
+
+func testAccResourceIPNatConfig(two_way bool) string {
+	return fmt.Sprintf(`
+resource "device42_ipam_subnet" "test" {
+  name      = "TF-ACC-TEST-NAT"
+  mask_bits = "29"
+  network   = "10.254.0.16"
+}
+
+resource "device42_ipam_ip" "external" {
+  subnet_id = device42_ipam_subnet.test.subnet_id
+  ipaddress = "10.254.0.17"
+}
+
+resource "device42_ipam_ip" "internal" {
+  subnet_id = device42_ipam_subnet.test.subnet_id
+  ipaddress = "10.254.0.18"
+}
+
+resource "device42_ip_nat" "test" {
+  name              = "TF-ACC-TEST-NAT"
+  ip_address_from   = device42_ipam_ip.external.ipaddress
+  ip_address_to     = device42_ipam_ip.internal.ipaddress
+  protocol          = "tcp"
+  source_port_start = 443
+  target_port_start = 8443
+  two_way_relation  = %t
+}
+`, two_way)
+}
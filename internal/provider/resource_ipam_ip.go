@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/poroping/libdevice42/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	ipam "github.com/poroping/libdevice42/client/ip_a_m"
 	"github.com/poroping/libdevice42/models"
 )
@@ -21,8 +23,12 @@ func resourceIpamIP() *schema.Resource {
 		UpdateContext: resourceIpamIPUpdate,
 		DeleteContext: resourceIpamIPDelete,
 
+		CustomizeDiff: resourceIpamIPCustomizeDiff,
+
 		Importer: nil,
 
+		Timeouts: resourceTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "IP address ID.",
@@ -36,10 +42,46 @@ func resourceIpamIP() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"available": {
+				Description: "Mark the IP as available.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Optional:    true,
+			},
+			"clear_all": {
+				Description:   "Mark the IP as available and clear device, MAC address, notes and label on create/update.",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"available", "device_id", "device_name", "label", "macaddress", "notes"},
+			},
+			"device_id": {
+				Description:   "ID of the device the IP belongs to.",
+				Type:          schema.TypeString,
+				Computed:      true,
+				Optional:      true,
+				ConflictsWith: []string{"device_name"},
+			},
+			"device_name": {
+				Description: "Name of the device the IP belongs to, can be new or existing.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+			},
+			"label": {
+				Description: "Label for the interface.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"macaddress": {
+				Description: "MAC address, can be new or existing.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+			},
 			"notes": {
 				Description: "Notes.",
 				Type:        schema.TypeString,
-				Computed:    true,
 				Optional:    true,
 			},
 			"subnet_id": {
@@ -48,21 +90,90 @@ func resourceIpamIP() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"tags": {
+				Description:      "Tags.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffFakeListEqual,
+			},
+			"type": {
+				Description:  "IP type. One of `static`, `dhcp` or `reserved`.",
+				Type:         schema.TypeString,
+				Computed:     true,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"static", "dhcp", "reserved"}, false),
+			},
+			"vrf_group": {
+				Description: "VRF group name.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+				ForceNew:    true,
+			},
 			"suggest_ip": {
-				Description:   "Get next free IP in subnet.",
-				Type:          schema.TypeBool,
-				Optional:      true,
-				Default:       false,
-				ConflictsWith: []string{"ipaddress"},
+				Description: "Get next free IP in subnet. Once allocated the address is kept in state, `ipaddress` takes precedence when set.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
 			},
+			"custom_fields": customFieldsSchema(),
+			"dns": {
+				Description: "Create DNS records for the address, an `A`/`AAAA` record and optionally a `PTR` record. The records are replaced when this changes and deleted with the IP.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Host name within the zone.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"zone": {
+							Description: "Forward DNS zone.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"reverse_zone": {
+							Description: "Reverse DNS zone to create the `PTR` record in, e.g. `0.10.in-addr.arpa`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"nameserver": {
+							Description: "Name server of the zones, to pick the view.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"ttl": {
+							Description: "TTL in seconds.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"dns_record_id": {
+				Description: "ID of the forward DNS record created with `dns`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"dns_ptr_record_id": {
+				Description: "ID of the PTR record created with `dns`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"on_existing":         onExistingSchema("IP"),
+			"adopted":             adoptedSchema("IP"),
+			"deletion_policy":     deletionPolicySchema("IP"),
+			"deletion_protection": deletionProtectionSchema("IP"),
 		},
 	}
 }
 
 func resourceIpamIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewPostIPAMIpsParams()
+	params := ipam.NewPostIPAMIpsParamsWithContext(ctx)
 
 	if v, ok := d.GetOk("ipaddress"); ok {
 		if s, ok := v.(string); ok {
@@ -70,7 +181,27 @@ func resourceIpamIPCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	if d.Get("suggest_ip").(bool) {
+	// serialize check/suggest/create in the same subnet so parallel applies
+	// can't be handed the same address, posting it again would take the
+	// first IP over.
+	lock_key := ipamIPLockKey(d)
+	ipamMutexKV.Lock(lock_key)
+	defer ipamMutexKV.Unlock(lock_key)
+
+	// a suggested address is free, only a configured one can already exist.
+	if params.Ipaddress != "" && onExistingPolicy(d) != "" {
+		err, ip_id := ipamIPCheckExist(ctx, d, meta, params.Ipaddress)
+
+		if err != nil {
+			return err
+		}
+
+		if ip_id != nil {
+			return adoptExisting(ctx, d, meta, "IP", *ip_id, resourceIpamIPUpdate, resourceIpamIPRead)
+		}
+	}
+
+	if d.Get("suggest_ip").(bool) && params.Ipaddress == "" {
 		err, ip := ipamSuggestIP(ctx, d, meta)
 		if err != nil {
 			return err
@@ -78,17 +209,21 @@ func resourceIpamIPCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		params.Ipaddress = *ip
 	}
 
-	if v, ok := d.GetOk("notes"); ok {
+	if v, ok := d.GetOk("subnet_id"); ok {
 		if s, ok := v.(string); ok {
-			params.Notes = &s
+			params.SubnetID = &s
 		}
 	}
-	if v, ok := d.GetOk("subnet_id"); ok {
+	if v, ok := d.GetOk("vrf_group"); ok {
 		if s, ok := v.(string); ok {
-			params.SubnetID = &s
+			params.VrfGroup = &s
 		}
 	}
 
+	if diags := expandIpamIP(ctx, d, meta, params); diags != nil {
+		return diags
+	}
+
 	resp, err := client.IPam.PostIPAMIps(params)
 
 	if err != nil {
@@ -104,13 +239,81 @@ func resourceIpamIPCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(string(msg[1]))
 
-	return resourceIpamIPRead(ctx, d, meta)
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "ip_address", d.Id())); diags != nil {
+		return diags
+	}
+
+	if diags := resourceIpamIPRead(ctx, d, meta); diags != nil {
+		return diags
+	}
+
+	return ipamIPUpdateDNS(ctx, d, meta)
+}
+
+// ipamIPLockKey returns the mutex key used to serialize address allocations in
+// the same subnet.
+func ipamIPLockKey(d *schema.ResourceData) string {
+	return fmt.Sprintf("device42_ipam_ip/subnet/%s", d.Get("subnet_id").(string))
+}
+
+func ipamIPCheckExist(ctx context.Context, d *schema.ResourceData, meta interface{}, ipaddress string) (diag.Diagnostics, *string) {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMIpsParamsWithContext(ctx)
+	params.SetIP(&ipaddress)
+
+	if v, ok := d.GetOk("subnet_id"); ok {
+		if s, ok := v.(string); ok {
+			params.SubnetID = &s
+		}
+	}
+
+	resp, err := client.IPam.GetIPAMIps(params)
+
+	if err != nil {
+		return diag.Errorf("error reading response. %s", err), nil
+	}
+
+	ips := resp.Payload.Ips
+
+	if len(ips) == 0 {
+		return nil, nil
+	}
+
+	if len(ips) > 1 {
+		return diag.Errorf("error multiple IPs found, filter better."), nil
+	}
+
+	ip_id := fmt.Sprint(ips[0].ID)
+
+	return nil, &ip_id
+}
+
+// resourceIpamIPCustomizeDiff makes the other of `device_id`/`device_name`
+// unknown when one changes. A suggested address needs nothing here, it stays in
+// state as `ipaddress` is computed and is only suggested again on create.
+func resourceIpamIPCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// device_id and device_name follow each other
+	if d.Id() != "" {
+		if d.HasChange("device_name") && !d.HasChange("device_id") {
+			if err := d.SetNewComputed("device_id"); err != nil {
+				return err
+			}
+		}
+		if d.HasChange("device_id") && !d.HasChange("device_name") {
+			if err := d.SetNewComputed("device_name"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func ipamSuggestIP(ctx context.Context, d *schema.ResourceData, meta interface{}) (diag.Diagnostics, *string) {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMSuggestIPParams()
+	params := ipam.NewGetIPAMSuggestIPParamsWithContext(ctx)
 
 	if v, ok := d.GetOk("subnet_id"); ok {
 		if s, ok := v.(string); ok {
@@ -130,9 +333,9 @@ func ipamSuggestIP(ctx context.Context, d *schema.ResourceData, meta interface{}
 }
 
 func resourceIpamIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMIpsParams()
+	params := ipam.NewGetIPAMIpsParamsWithContext(ctx)
 	id := d.Id()
 	params.SetIPID(&id)
 
@@ -152,24 +355,232 @@ func resourceIpamIPRead(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.Errorf("error more than one IP found.")
 	}
 
-	setIpamIP(d, resp.Payload.Ips[0])
+	ip := resp.Payload.Ips[0]
+
+	setIpamIP(d, ip)
+
+	if v, ok := d.GetOk("custom_fields"); ok {
+		d.Set("custom_fields", flattenCustomFields(ipamIPCustomFields(ip), v.(map[string]interface{})))
+	}
+
+	if v, ok := ip.SubnetID.(json.Number); ok {
+		vrf_group, diags := ipamIPReadVrfGroup(ctx, meta, v)
+		if diags != nil {
+			return diags
+		}
+		d.Set("vrf_group", vrf_group)
+	}
+
+	tags, diags := ipamIPReadTags(ctx, meta, id, d.Get("tags").(string))
+	if diags != nil {
+		return diags
+	}
+	d.Set("tags", tags)
 
 	return nil
 }
 
+// ipamIPCustomFields converts the IP custom fields for flattenCustomFields.
+func ipamIPCustomFields(ip *models.IPAMips) []*customField {
+	fields := make([]*customField, 0, len(ip.CustomFields))
+	for _, f := range ip.CustomFields {
+		if f == nil {
+			continue
+		}
+		fields = append(fields, &customField{Key: f.Key, Notes: f.Notes, Value: f.Value})
+	}
+	return fields
+}
+
+// ipamIPCustomFieldParams selects an IP for its custom field endpoint, which
+// takes the address and subnet instead of the ID.
+func ipamIPCustomFieldParams(ctx context.Context, meta interface{}, id string) (formParams, diag.Diagnostics) {
+	ip, diags := getIpamIP(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	return formParams{
+		"ip_address": stringOrEmpty(ip.IP),
+		"subnet_id":  stringOrNumber(ip.SubnetID),
+	}, nil
+}
+
+func ipamIPCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	ip, diags := getIpamIP(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	return ipamIPCustomFields(ip), nil
+}
+
+func getIpamIP(ctx context.Context, meta interface{}, id string) (*models.IPAMips, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMIpsParamsWithContext(ctx)
+	params.SetIPID(&id)
+
+	resp, err := client.IPam.GetIPAMIps(params)
+
+	if err != nil {
+		return nil, diag.Errorf("error reading IPAM IP %s. %s", id, err)
+	}
+
+	if len(resp.Payload.Ips) != 1 || resp.Payload.Ips[0] == nil {
+		return nil, diag.Errorf("error IP %s not found.", id)
+	}
+
+	return resp.Payload.Ips[0], nil
+}
+
+// ipamIPReadVrfGroup returns the VRF group of the subnet holding the IP, the
+// IP endpoint does not return it.
+func ipamIPReadVrfGroup(ctx context.Context, meta interface{}, subnet_id json.Number) (string, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	i, err := subnet_id.Int64()
+	if err != nil {
+		return "", diag.Errorf("error getting subnetid. %s", err)
+	}
+
+	params := ipam.NewGetIPAMSubnetIDParamsWithContext(ctx)
+	params.SetSubnetID(i)
+
+	resp, err := client.IPam.GetIPAMSubnetID(params)
+
+	if err != nil {
+		return "", diag.Errorf("error reading IPAM subnet. %s", err)
+	}
+
+	if v, ok := resp.Payload.VrfGroupName.(string); ok {
+		return v, nil
+	}
+
+	return "", nil
+}
+
+// ipamIPReadTags works out which of the known tags are still present on the IP.
+// The IP endpoint does not return tags so they are checked with the tags_and
+// filter, any tag that no longer matches is dropped so the drift shows in plan.
+func ipamIPReadTags(ctx context.Context, meta interface{}, id, tags string) (string, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	current := deleteEmpty(strings.Split(tags, ","))
+
+	if len(current) == 0 {
+		return tags, nil
+	}
+
+	hasTags := func(t string) (bool, diag.Diagnostics) {
+		params := ipam.NewGetIPAMIpsParamsWithContext(ctx)
+		params.SetIPID(&id)
+		params.SetTagsAnd(&t)
+
+		resp, err := client.IPam.GetIPAMIps(params)
+
+		if err != nil {
+			return false, diag.Errorf("error reading IPAM IP tags. %s", err)
+		}
+
+		return len(resp.Payload.Ips) > 0, nil
+	}
+
+	all, diags := hasTags(strings.Join(current, ","))
+	if diags != nil || all {
+		return tags, diags
+	}
+
+	found := make([]string, 0)
+
+	for _, t := range current {
+		ok, diags := hasTags(t)
+		if diags != nil {
+			return "", diags
+		}
+		if ok {
+			found = append(found, t)
+		}
+	}
+
+	return strings.Join(found, ","), nil
+}
+
 func resourceIpamIPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	if adoptedReadonly(d) {
+		return diag.Errorf("error IP %s is adopted read-only, set on_existing = \"adopt\" to manage it.", d.Id())
+	}
+
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewPostIPAMIpsParams()
+	params := ipam.NewPostIPAMIpsParamsWithContext(ctx)
 	id := d.Id()
 	params.SetIPID(&id)
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "label", "notes", "tags")...))
 
-	if v, ok := d.GetOk("notes"); ok {
-		if s, ok := v.(string); ok {
-			params.Notes = &s
+	unlink := false
+
+	if d.Get("clear_all").(bool) {
+		params.ClearAll = yesNo(true)
+	}
+	if d.HasChange("available") {
+		params.Available = yesNo(d.Get("available").(bool))
+	}
+	if d.HasChange("device_id") || d.HasChange("device_name") {
+		v, diags := ipamIPDevice(ctx, d, meta)
+		if diags != nil {
+			return diags
 		}
+		if v != "" {
+			params.Device = &v
+		} else {
+			unlink = true
+		}
+	}
+	if d.HasChange("label") {
+		v := d.Get("label").(string)
+		params.Label = &v
+	}
+	if d.HasChange("macaddress") {
+		if v := d.Get("macaddress").(string); v != "" {
+			params.Macaddress = &v
+		} else {
+			unlink = true
+		}
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+	if d.HasChange("tags") {
+		v := d.Get("tags").(string)
+		params.Tags = &v
+	}
+	if d.HasChange("type") {
+		v := d.Get("type").(string)
+		params.Type = &v
 	}
 
+	// the device or MAC can't be cleared on their own, clear everything and
+	// send back what is kept.
+	if unlink {
+		keep := func(p **string, k string, changed ...string) {
+			if *p != nil || d.HasChanges(changed...) {
+				return
+			}
+			if v := d.Get(k).(string); v != "" {
+				*p = &v
+			}
+		}
+		params.ClearAll = yesNo(true)
+		params.Available = yesNo(d.Get("available").(bool))
+		keep(&params.Device, "device_name", "device_id", "device_name")
+		keep(&params.Label, "label", "label")
+		keep(&params.Macaddress, "macaddress", "macaddress")
+		keep(&params.Notes, "notes", "notes")
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
 	resp, err := client.IPam.PostIPAMIps(params)
 
 	if err != nil {
@@ -185,19 +596,53 @@ func resourceIpamIPUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(string(msg[1]))
 
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "ip_address", d.Id())); diags != nil {
+		return diags
+	}
+
+	if diags := ipamIPUpdateDNS(ctx, d, meta); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
 	return resourceIpamIPRead(ctx, d, meta)
 }
 
 func resourceIpamIPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	if abandonOnDestroy(d, meta, "IP") {
+		d.SetId("")
+		return nil
+	}
+
+	if diags := checkDeletionProtection(d, "IP"); diags != nil {
+		return diags
+	}
+
+	if diags := ipamIPDeleteDNS(ctx, d, meta); diags != nil {
+		return diags
+	}
 
-	params := ipam.NewDeleteIPAMIpsParams()
 	id := d.Id()
 	i, err := strconv.Atoi(id)
 	if err != nil {
 		return diag.Errorf("error getting IP ID. %s", err)
 	}
-	params.SetID(int64(i))
+
+	if diags := ipamIPsDeleteID(ctx, meta, int64(i)); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func ipamIPsDeleteID(ctx context.Context, meta interface{}, ip_id int64) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewDeleteIPAMIpsParamsWithContext(ctx)
+	params.SetID(ip_id)
 
 	resp, err := client.IPam.DeleteIPAMIps(params)
 
@@ -216,16 +661,167 @@ func resourceIpamIPDelete(ctx context.Context, d *schema.ResourceData, meta inte
 		return diag.Errorf("error deleting IPAM IP.")
 	}
 
-	d.SetId("")
+	return nil
+}
+
+func expandIpamIP(ctx context.Context, d *schema.ResourceData, meta interface{}, params *ipam.PostIPAMIpsParams) diag.Diagnostics {
+	if d.Get("clear_all").(bool) {
+		params.ClearAll = yesNo(true)
+	}
+	if v, ok := d.GetOk("available"); ok {
+		if b, ok := v.(bool); ok {
+			params.Available = yesNo(b)
+		}
+	}
+	if v, diags := ipamIPDevice(ctx, d, meta); diags != nil {
+		return diags
+	} else if v != "" {
+		params.Device = &v
+	}
+	if v, ok := d.GetOk("label"); ok {
+		if s, ok := v.(string); ok {
+			params.Label = &s
+		}
+	}
+	if v, ok := d.GetOk("macaddress"); ok {
+		if s, ok := v.(string); ok {
+			params.Macaddress = &s
+		}
+	}
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		if s, ok := v.(string); ok {
+			params.Tags = &s
+		}
+	}
+	if v, ok := d.GetOk("type"); ok {
+		if s, ok := v.(string); ok {
+			params.Type = &s
+		}
+	}
 
 	return nil
 }
 
+// ipamIPDevice returns the name of the device the IP should belong to, looked
+// up when it is configured by ID.
+func ipamIPDevice(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, diag.Diagnostics) {
+	if d.HasChange("device_id") {
+		if v := d.Get("device_id").(string); v != "" {
+			return deviceName(ctx, meta, v)
+		}
+	}
+	return d.Get("device_name").(string), nil
+}
+
 func setIpamIP(d *schema.ResourceData, resp *models.IPAMips) {
+	if v, ok := parseYesNo(resp.Available); ok {
+		d.Set("available", v)
+	}
+	d.Set("device_name", stringOrEmpty(resp.Device))
+	if resp.DeviceID != nil {
+		d.Set("device_id", fmt.Sprint(resp.DeviceID))
+	} else {
+		d.Set("device_id", "")
+	}
 	if v, ok := resp.IP.(string); ok {
 		d.Set("ipaddress", v)
 	}
+	if v, ok := resp.Label.(string); ok {
+		d.Set("label", v)
+	}
+	d.Set("macaddress", stringOrEmpty(resp.MacAddress))
 	if v, ok := resp.Notes.(string); ok {
 		d.Set("notes", v)
 	}
+	if v, ok := resp.SubnetID.(json.Number); ok {
+		d.Set("subnet_id", v.String())
+	}
+	if v, ok := resp.Type.(string); ok {
+		d.Set("type", strings.ToLower(v))
+	}
+}
+
+// ipamIPUpdateDNS replaces the DNS records of the IP when `dns` changed.
+func ipamIPUpdateDNS(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("dns") {
+		return nil
+	}
+
+	if diags := ipamIPDeleteDNS(ctx, d, meta); diags != nil {
+		return diags
+	}
+
+	l := d.Get("dns").([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	m := l[0].(map[string]interface{})
+	ip := d.Get("ipaddress").(string)
+
+	record := dnsRecord{
+		zone:       m["zone"].(string),
+		nameserver: m["nameserver"].(string),
+		name:       m["name"].(string),
+		content:    ip,
+		ttl:        m["ttl"].(int),
+	}
+
+	record_type, err := forwardRecordType(ip)
+	if err != nil {
+		return diag.Errorf("error creating DNS record. %s", err)
+	}
+	record.recordType = record_type
+
+	id, err := createDNSRecord(ctx, client, record)
+	if err != nil {
+		return diag.Errorf("error creating %s record %s.%s. %s", record.recordType, record.name, record.zone, err)
+	}
+	d.Set("dns_record_id", id)
+
+	if v := m["reverse_zone"].(string); v != "" {
+		name, err := reverseRecordName(ip, v)
+		if err != nil {
+			return diag.Errorf("error creating PTR record. %s", err)
+		}
+
+		ptr := record
+		ptr.zone = v
+		ptr.recordType = "PTR"
+		ptr.name = name
+		ptr.content = recordFQDN(record.name, record.zone)
+
+		id, err := createDNSRecord(ctx, client, ptr)
+		if err != nil {
+			return diag.Errorf("error creating PTR record %s.%s. %s", ptr.name, ptr.zone, err)
+		}
+		d.Set("dns_ptr_record_id", id)
+	}
+
+	return nil
+}
+
+// ipamIPDeleteDNS deletes the DNS records created for the IP.
+func ipamIPDeleteDNS(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	for _, k := range []string{"dns_record_id", "dns_ptr_record_id"} {
+		id := d.Get(k).(string)
+		if id == "" {
+			continue
+		}
+		if err := deleteDNSRecord(ctx, client, id); err != nil {
+			return diag.Errorf("error deleting DNS record %s. %s", id, err)
+		}
+		d.Set(k, "")
+	}
+
+	return nil
 }
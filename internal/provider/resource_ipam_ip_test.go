@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/poroping/libdevice42/client"
+)
+
+func TestIpamIPSuggestedAddressSticky(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "12",
+		Attributes: map[string]string{
+			"id":         "12",
+			"ipaddress":  "10.254.0.3",
+			"subnet_id":  "7",
+			"suggest_ip": "true",
+		},
+	}
+
+	var tests = []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{"suggest_ip", map[string]interface{}{"subnet_id": "7", "suggest_ip": true}},
+		{"pinned to the suggested address", map[string]interface{}{"subnet_id": "7", "suggest_ip": true, "ipaddress": "10.254.0.3"}},
+		{"suggest_ip removed", map[string]interface{}{"subnet_id": "7", "ipaddress": "10.254.0.3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := resourceIpamIP().Diff(context.Background(), state, terraform.NewResourceConfigRaw(tt.config), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil {
+				return
+			}
+			if diff.RequiresNew() {
+				t.Errorf("plan replaces the IP: %v", diff.Attributes)
+			}
+			if a, ok := diff.Attributes["ipaddress"]; ok && (a.NewComputed || a.New != "10.254.0.3") {
+				t.Errorf("plan changes ipaddress: %+v", a)
+			}
+		})
+	}
+
+	diff, err := resourceIpamIP().Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{"subnet_id": "7", "ipaddress": "10.254.0.4"}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Errorf("a different ipaddress should replace the IP")
+	}
+}
+
+func TestIpamIPCreateLocksSubnet(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/1.0/suggest_ip/":
+			fmt.Fprint(w, `{"ip": "10.0.0.5"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	meta := &apiClient{
+		Device42: client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/",
+			Schemes:  []string{"http"},
+		}),
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceIpamIP().Schema, map[string]interface{}{"subnet_id": "7", "suggest_ip": true})
+
+	// another create in the subnet holds the lock.
+	lock_key := ipamIPLockKey(d)
+	ipamMutexKV.Lock(lock_key)
+
+	done := make(chan struct{})
+	go func() {
+		resourceIpamIPCreate(context.Background(), d, meta)
+		close(done)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	if requests != nil {
+		t.Errorf("requests %v sent while the subnet is locked", requests)
+	}
+	mu.Unlock()
+
+	ipamMutexKV.Unlock(lock_key)
+	<-done
+
+	if len(requests) == 0 || requests[0] != "GET /api/1.0/suggest_ip/" {
+		t.Errorf("requests %v, want the suggestion first", requests)
+	}
+}
+
+func TestIpamIPDeleteAbandon(t *testing.T) {
+	var requests []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	meta := &apiClient{
+		Device42: client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/",
+			Schemes:  []string{"http"},
+		}),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		config  map[string]interface{}
+		adopted string
+	}{
+		{"linked", map[string]interface{}{"device_name": "web-01", "macaddress": "02:00:00:00:00:01", "label": "eth0"}, ""},
+		{"adopted", map[string]interface{}{"device_name": "web-01"}, onExistingAdopt},
+		{"adopted read-only", map[string]interface{}{"device_name": "web-01"}, onExistingAdoptReadonly},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+
+			tt.config["deletion_policy"] = deletionPolicyAbandon
+			d := schema.TestResourceDataRaw(t, resourceIpamIP().Schema, tt.config)
+			d.SetId("12")
+			d.Set("adopted", tt.adopted)
+
+			if diags := resourceIpamIPDelete(context.Background(), d, meta); diags != nil {
+				t.Fatalf("resourceIpamIPDelete: %v", diags)
+			}
+
+			if d.Id() != "" {
+				t.Errorf("the IP is still in state")
+			}
+			if requests != nil {
+				t.Errorf("abandoning the IP sent %v", requests)
+			}
+		})
+	}
+}
+
+func TestAccResourceIpamIP_clearNotes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIpamIPConfig(`notes = "tf-acc-test"
+  label = "eth0"
+  tags  = "TF-ACC-TEST"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_ip.test", "tags", "TF-ACC-TEST"),
+					resource.TestCheckResourceAttr("device42_ipam_ip.test", "notes", "tf-acc-test"),
+					resource.TestCheckResourceAttr("device42_ipam_ip.test", "label", "eth0"),
+				),
+			},
+			{
+				Config: testAccResourceIpamIPConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_ip.test", "notes", ""),
+					resource.TestCheckResourceAttr("device42_ipam_ip.test", "label", ""),
+					resource.TestCheckResourceAttr("device42_ipam_ip.test", "tags", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIpamIP_dns(t *testing.T) {
+	zone := fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandString(8))
+	var record_id, ptr_record_id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIpamIPDNSConfig(zone, "host1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("device42_ipam_ip.test", "dns_record_id"),
+					resource.TestCheckResourceAttrSet("device42_ipam_ip.test", "dns_ptr_record_id"),
+					testAccStoreResourceAttr("device42_ipam_ip.test", "dns_record_id", &record_id),
+					testAccStoreResourceAttr("device42_ipam_ip.test", "dns_ptr_record_id", &ptr_record_id),
+				),
+			},
+			{
+				Config: testAccResourceIpamIPDNSConfig(zone, "host2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("device42_ipam_ip.test", "dns_record_id"),
+					resource.TestCheckResourceAttrSet("device42_ipam_ip.test", "dns_ptr_record_id"),
+					testAccCheckResourceAttrChanged("device42_ipam_ip.test", "dns_record_id", &record_id),
+					testAccCheckResourceAttrChanged("device42_ipam_ip.test", "dns_ptr_record_id", &ptr_record_id),
+				),
+			},
+		},
+	})
+}
+
+// testAccStoreResourceAttr saves an attribute for a later step to compare.
+func testAccStoreResourceAttr(name, key string, v *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found", name)
+		}
+		*v = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccCheckResourceAttrChanged checks an attribute differs from the one
+// saved with testAccStoreResourceAttr.
+func testAccCheckResourceAttrChanged(name, key string, v *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found", name)
+		}
+		if got := rs.Primary.Attributes[key]; got == *v {
+			return fmt.Errorf("%s: %s is still %s", name, key, got)
+		}
+		return nil
+	}
+}
+
+func testAccResourceIpamIPConfig(extra string) string {
+	return fmt.Sprintf(`
+resource "device42_ipam_subnet" "test" {
+  name      = "TF-ACC-TEST-IP"
+  mask_bits = "29"
+  network   = "10.254.0.0"
+}
+
+resource "device42_ipam_ip" "test" {
+  subnet_id = device42_ipam_subnet.test.subnet_id
+  ipaddress = "10.254.0.1"
+  %s
+}
+`, extra)
+}
+
+func testAccResourceIpamIPDNSConfig(zone, host string) string {
+	return fmt.Sprintf(`
+resource "device42_dns_zone" "forward" {
+  name       = "%s"
+  nameserver = "ns1.example.com"
+}
+
+resource "device42_dns_zone" "reverse" {
+  name       = "0.254.10.in-addr.arpa"
+  nameserver = "ns1.example.com"
+}
+`, zone) + testAccResourceIpamIPConfig(fmt.Sprintf(`
+  dns {
+    name         = "%s"
+    zone         = device42_dns_zone.forward.name
+    reverse_zone = device42_dns_zone.reverse.name
+    nameserver   = "ns1.example.com"
+  }`, host))
+}
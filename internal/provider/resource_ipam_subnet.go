@@ -3,16 +3,21 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/poroping/libdevice42/client"
 	ipam "github.com/poroping/libdevice42/client/ip_a_m"
 	"github.com/poroping/libdevice42/models"
 )
 
+// ipamSubnetsCreateChildAttempts is how many times a child allocation is retried
+// when Device42 hands out a block that overlaps an existing sibling.
+const ipamSubnetsCreateChildAttempts = 5
+
 func resourceIpamSubnet() *schema.Resource {
 	return &schema.Resource{
 		Description: "Manage IPAM subnets.",
@@ -24,6 +29,8 @@ func resourceIpamSubnet() *schema.Resource {
 
 		Importer: nil,
 
+		Timeouts: resourceTimeouts(),
+
 		Schema: map[string]*schema.Schema{
 			"mask_bits": {
 				Description: "Netmask bits.",
@@ -31,16 +38,26 @@ func resourceIpamSubnet() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"customer": {
+				Description:   "Customer name, looked up to set `customer_id`. Removing it clears the customer of the subnet.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"customer_id"},
+			},
 			"customer_id": {
-				Description: "Customer ID.",
+				Description: "Customer ID. Left empty when the customer is set with `customer`, see `resolved_customer_id`.",
 				Type:        schema.TypeString,
-				Computed:    true,
 				Optional:    true,
 			},
+			"resolved_customer_id": {
+				Description: "ID of the customer of the subnet in Device42, whether set with `customer` or `customer_id`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"custom_fields": customFieldsSchema(),
 			"name": {
 				Description: "Name.",
 				Type:        schema.TypeString,
-				Computed:    true,
 				Optional:    true,
 			},
 			"network": {
@@ -65,7 +82,6 @@ func resourceIpamSubnet() *schema.Resource {
 			"parent_vlan_id": {
 				Description: "Parent vlan ID.",
 				Type:        schema.TypeString,
-				Computed:    true,
 				Optional:    true,
 			},
 			"parent_vlan_name": {
@@ -87,7 +103,6 @@ func resourceIpamSubnet() *schema.Resource {
 			"tags": {
 				Description:      "Tags.",
 				Type:             schema.TypeString,
-				Computed:         true,
 				Optional:         true,
 				DiffSuppressFunc: diffFakeListEqual,
 			},
@@ -98,29 +113,40 @@ func resourceIpamSubnet() *schema.Resource {
 				Default:      false,
 				RequiredWith: []string{"parent_subnet_id"},
 			},
+			"force_delete": {
+				Description: "Delete the subnet on destroy even if it still has child subnets or allocated IPs, deleting those first.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"check_if_exists": {
-				Description: "Use to check if subnet exists already.",
+				Description: "Use to check if subnet exists already. Same as `on_existing = \"adopt\"`, which takes precedence.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 			},
+			"on_existing":         onExistingSchema("subnet"),
+			"adopted":             adoptedSchema("subnet"),
+			"deletion_policy":     deletionPolicySchema("subnet"),
+			"deletion_protection": deletionProtectionSchema("subnet"),
 		},
 	}
 }
 
 func resourceIpamSubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
 	if v, ok := d.GetOk("subnet_id"); ok {
-		if s, ok := v.(string); ok {
-			d.SetId(s)
-			return resourceIpamSubnetUpdate(ctx, d, meta)
-		}
+		return adoptExisting(ctx, d, meta, "subnet", v.(string), resourceIpamSubnetUpdate, resourceIpamSubnetRead)
 	}
 
-	params := ipam.NewPostIPAMsubnetsParams()
+	// serialize check/create against the same parent so parallel applies
+	// can't be handed the same free block.
+	lock_key := ipamSubnetLockKey(d)
+	ipamMutexKV.Lock(lock_key)
+	defer ipamMutexKV.Unlock(lock_key)
 
-	if d.Get("check_if_exists").(bool) {
+	if onExistingPolicy(d) != "" {
 		err, subnet_id := ipamSubnetsCheckExist(ctx, d, meta)
 
 		if err != nil {
@@ -128,48 +154,27 @@ func resourceIpamSubnetCreate(ctx context.Context, d *schema.ResourceData, meta
 		}
 
 		if subnet_id != nil {
-			params.SubnetID = subnet_id
-
-			subnet_id_int, err := strconv.Atoi(*subnet_id)
-
-			if err != nil {
-				return diag.Errorf("error reading subnet_id. %s", err)
-			}
-
-			read_params := ipam.NewGetIPAMSubnetIDParams()
-			read_params.SetSubnetID(int64(subnet_id_int))
-
-			resp2, err := client.IPam.GetIPAMSubnetID(read_params)
-
-			if err != nil {
-				return diag.Errorf("error reading IPAM subnet. %s", err)
-			}
-
-			if v, ok := resp2.Payload.MaskBits.(string); ok {
-				d.Set("mask_bits", v)
-			}
-
-			if v, ok := resp2.Payload.Network.(string); ok {
-				d.Set("network", v)
-			}
+			return adoptExisting(ctx, d, meta, "subnet", *subnet_id, resourceIpamSubnetUpdate, resourceIpamSubnetRead)
 		}
 	}
 
 	if d.Get("create_from_parent").(bool) {
-		if params.SubnetID == nil {
-			return ipamSubnetsCreateChildCreate(ctx, d, meta)
-		}
+		return ipamSubnetsCreateChildCreate(ctx, d, meta)
 	}
 
+	params := ipam.NewPostIPAMsubnetsParamsWithContext(ctx)
+
 	if v, ok := d.GetOk("mask_bits"); ok {
 		if s, ok := v.(string); ok {
 			params.MaskBits = s
 		}
 	}
-	if v, ok := d.GetOk("customer_id"); ok {
-		if s, ok := v.(string); ok {
-			params.CustomerID = &s
-		}
+	customer_id, diags := ipamSubnetCustomerID(ctx, d, meta)
+	if diags != nil {
+		return diags
+	}
+	if customer_id != "" {
+		params.CustomerID = &customer_id
 	}
 	if v, ok := d.GetOk("name"); ok {
 		if s, ok := v.(string); ok {
@@ -217,61 +222,123 @@ func resourceIpamSubnetCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(string(msg[1]))
 
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "subnet", d.Id())); diags != nil {
+		return diags
+	}
+
 	return resourceIpamSubnetRead(ctx, d, meta)
 }
 
 func ipamSubnetsCreateChildCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewPostIPAMSubnetsCreateChildParams()
+	parent_subnet_id := d.Get("parent_subnet_id").(string)
 
-	if v, ok := d.GetOk("parent_subnet_id"); ok {
-		if s, ok := v.(string); ok {
-			params.ParentSubnetID = &s
+	for attempt := 1; attempt <= ipamSubnetsCreateChildAttempts; attempt++ {
+		params := ipam.NewPostIPAMSubnetsCreateChildParamsWithContext(ctx)
+		params.ParentSubnetID = &parent_subnet_id
+
+		if v, ok := d.GetOk("mask_bits"); ok {
+			if s, ok := v.(string); ok {
+				params.MaskBits = s
+			}
 		}
-	}
 
-	if v, ok := d.GetOk("mask_bits"); ok {
-		if s, ok := v.(string); ok {
-			params.MaskBits = s
+		resp, err := client.IPam.PostIPAMSubnetsCreateChild(params)
+
+		if err != nil {
+			return diag.Errorf("error creating child subnet. %s", err)
 		}
-	}
 
-	resp, err := client.IPam.PostIPAMSubnetsCreateChild(params)
+		subnet_id, err := resp.Payload.SubnetID.(json.Number).Int64()
 
-	if err != nil {
-		return diag.Errorf("error creating child subnet. %s", err)
+		if err != nil {
+			return diag.Errorf("error read child subnet_id. %s", err)
+		}
+
+		read_params := ipam.NewGetIPAMSubnetIDParamsWithContext(ctx)
+		read_params.SetSubnetID(subnet_id)
+
+		resp2, err := client.IPam.GetIPAMSubnetID(read_params)
+
+		if err != nil {
+			return diag.Errorf("error reading IPAM subnet. %s", err)
+		}
+
+		network := fmt.Sprint(resp2.Payload.Network)
+		mask_bits := fmt.Sprint(resp2.Payload.MaskBits)
+
+		overlap, diags := ipamSubnetsSiblingOverlap(ctx, meta, parent_subnet_id, subnet_id, network, mask_bits)
+
+		if diags != nil {
+			return diags
+		}
+
+		if !overlap {
+			d.SetId(strconv.FormatInt(subnet_id, 10))
+			d.Set("network", network)
+
+			return resourceIpamSubnetUpdate(ctx, d, meta)
+		}
+
+		log.Printf("[WARN] child subnet %s/%s (%d) overlaps a sibling in parent %s, retrying (%d/%d)", network, mask_bits, subnet_id, parent_subnet_id, attempt, ipamSubnetsCreateChildAttempts)
+
+		if diags := ipamSubnetsDeleteID(ctx, meta, subnet_id); diags != nil {
+			return diags
+		}
 	}
 
-	subnet_id, err := resp.Payload.SubnetID.(json.Number).Int64()
+	return diag.Errorf("error creating child subnet. allocation in parent %s still overlapping after %d attempts.", parent_subnet_id, ipamSubnetsCreateChildAttempts)
+}
+
+// ipamSubnetsSiblingOverlap checks a freshly allocated child against the other
+// children of its parent. The older (lower ID) allocation wins so that two
+// colliding writers don't both back off.
+func ipamSubnetsSiblingOverlap(ctx context.Context, meta interface{}, parent_subnet_id string, subnet_id int64, network, mask_bits string) (bool, diag.Diagnostics) {
+	siblings, err := ipamSubnetsChildren(ctx, meta, parent_subnet_id)
 
 	if err != nil {
-		return diag.Errorf("error read child subnet_id. %s", err)
+		return false, diag.Errorf("error reading sibling subnets. %s", err)
 	}
 
-	subnet_id_s := resp.Payload.SubnetID.(json.Number).String()
-	d.SetId(subnet_id_s)
+	for _, sibling := range siblings {
+		sibling_id, err := sibling.SubnetID.(json.Number).Int64()
+
+		if err != nil {
+			return false, diag.Errorf("error reading sibling subnet_id. %s", err)
+		}
+
+		if sibling_id == subnet_id {
+			continue
+		}
 
-	read_params := ipam.NewGetIPAMSubnetIDParams()
-	read_params.SetSubnetID(subnet_id)
+		overlap, err := subnetsOverlap(network, mask_bits, fmt.Sprint(sibling.Network), fmt.Sprint(sibling.MaskBits))
 
-	resp2, err := client.IPam.GetIPAMSubnetID(read_params)
+		if err != nil {
+			return false, diag.Errorf("error comparing sibling subnets. %s", err)
+		}
 
-	if err != nil {
-		return diag.Errorf("error reading IPAM subnet. %s", err)
+		if overlap && sibling_id < subnet_id {
+			return true, nil
+		}
 	}
 
-	if v, ok := resp2.Payload.Network.(string); ok {
-		d.Set("network", v)
-	}
+	return false, nil
+}
 
-	return resourceIpamSubnetUpdate(ctx, d, meta)
+// ipamSubnetLockKey returns the mutex key used to serialize allocations in the
+// same parent subnet.
+func ipamSubnetLockKey(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("parent_subnet_id"); ok {
+		return fmt.Sprintf("device42_ipam_subnet/parent/%s", v.(string))
+	}
+	return "device42_ipam_subnet"
 }
 
 func ipamSubnetsCheckExist(ctx context.Context, d *schema.ResourceData, meta interface{}) (diag.Diagnostics, *string) {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMsubnetsParams()
+	params := ipam.NewGetIPAMsubnetsParamsWithContext(ctx)
 
 	if v, ok := d.GetOk("mask_bits"); ok {
 		if s, ok := v.(string); ok {
@@ -325,9 +392,9 @@ func ipamSubnetsCheckExist(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func resourceIpamSubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMSubnetIDParams()
+	params := ipam.NewGetIPAMSubnetIDParamsWithContext(ctx)
 	id := d.Id()
 	i, err := strconv.Atoi(id)
 	if err != nil {
@@ -343,53 +410,133 @@ func resourceIpamSubnetRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	setIpamSubnet(d, resp.Payload)
 
+	if v, ok := d.GetOk("custom_fields"); ok {
+		d.Set("custom_fields", flattenCustomFields(customFieldList(resp.Payload.CustomFields), v.(map[string]interface{})))
+	}
+
+	customer_id := d.Get("customer_id").(string)
+	d.Set("resolved_customer_id", customer_id)
+
+	// only look the name up when it's used in place of customer_id, which
+	// then stays empty as it isn't configured.
+	if _, ok := d.GetOk("customer"); ok {
+		name, diags := customerName(ctx, meta, customer_id)
+		if diags != nil {
+			return diags
+		}
+		d.Set("customer", name)
+		d.Set("customer_id", "")
+	}
+
 	return nil
 }
 
+// ipamSubnetCustomerID returns the configured customer_id, or the ID of the
+// configured customer name.
+func ipamSubnetCustomerID(ctx context.Context, d *schema.ResourceData, meta interface{}) (string, diag.Diagnostics) {
+	if v, ok := d.GetOk("customer"); ok {
+		return customerID(ctx, meta, v.(string))
+	}
+	return d.Get("customer_id").(string), nil
+}
+
+// ipamSubnetCustomFieldParams selects a subnet for its custom field endpoint,
+// which takes the network, mask bits and VRF group instead of the ID.
+func ipamSubnetCustomFieldParams(ctx context.Context, meta interface{}, id string) (formParams, diag.Diagnostics) {
+	subnet, diags := getIpamSubnet(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	return formParams{
+		"network":   stringOrEmpty(subnet.Network),
+		"mask_bits": stringOrNumber(subnet.MaskBits),
+		"vrf_group": stringOrEmpty(subnet.VrfGroupName),
+	}, nil
+}
+
+func ipamSubnetCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	subnet, diags := getIpamSubnet(ctx, meta, id)
+	if diags != nil {
+		return nil, diags
+	}
+	return customFieldList(subnet.CustomFields), nil
+}
+
+func getIpamSubnet(ctx context.Context, meta interface{}, id string) (*models.IPAMsubnets, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, diag.Errorf("error getting subnetid. %s", err)
+	}
+
+	params := ipam.NewGetIPAMSubnetIDParamsWithContext(ctx)
+	params.SetSubnetID(i)
+
+	resp, err := client.IPam.GetIPAMSubnetID(params)
+
+	if err != nil {
+		return nil, diag.Errorf("error reading IPAM subnet %s. %s", id, err)
+	}
+
+	return resp.Payload, nil
+}
+
 func resourceIpamSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	if adoptedReadonly(d) {
+		return diag.Errorf("error subnet %s is adopted read-only, set on_existing = \"adopt\" to manage it.", d.Id())
+	}
+
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewPostIPAMsubnetsParams()
+	params := ipam.NewPostIPAMsubnetsParamsWithContext(ctx)
 	id := d.Id()
 
 	params.SubnetID = &id
+	cleared := changedToEmpty(d, "name", "parent_vlan_id", "tags")
 
-	if v, ok := d.GetOk("mask_bits"); ok {
-		if s, ok := v.(string); ok {
-			params.MaskBits = s
+	if d.HasChanges("customer", "customer_id") {
+		v, diags := ipamSubnetCustomerID(ctx, d, meta)
+		if diags != nil {
+			return diags
 		}
-	}
-	if v, ok := d.GetOk("customer_id"); ok {
-		if s, ok := v.(string); ok {
-			params.CustomerID = &s
+		if v != "" {
+			params.CustomerID = &v
+		} else {
+			cleared = append(cleared, "customer_id")
 		}
 	}
-	if v, ok := d.GetOk("name"); ok {
-		if s, ok := v.(string); ok {
-			params.Name = &s
-		}
+
+	params.SetContext(withClearedFormParams(ctx, cleared...))
+
+	// only send what changed, re-posting network/mask_bits/parent_subnet_id
+	// can re-parent or fail validation if the subnet was edited in Device42.
+	if d.HasChange("mask_bits") {
+		params.MaskBits = d.Get("mask_bits").(string)
 	}
-	if v, ok := d.GetOk("network"); ok {
-		if s, ok := v.(string); ok {
-			params.Network = s
-		}
+	if d.HasChange("name") {
+		v := d.Get("name").(string)
+		params.Name = &v
 	}
-	if v, ok := d.GetOk("parent_subnet_id"); ok {
-		if s, ok := v.(string); ok {
-			params.ParentSubnetID = &s
-		}
+	if d.HasChange("network") {
+		params.Network = d.Get("network").(string)
 	}
-	if v, ok := d.GetOk("parent_vlan_id"); ok {
-		if s, ok := v.(string); ok {
-			params.ParentVlanID = &s
-		}
+	if d.HasChange("parent_subnet_id") {
+		v := d.Get("parent_subnet_id").(string)
+		params.ParentSubnetID = &v
 	}
-	if v, ok := d.GetOk("tags"); ok {
-		if s, ok := v.(string); ok {
-			params.Tags = &s
-		}
+	if d.HasChange("parent_vlan_id") {
+		v := d.Get("parent_vlan_id").(string)
+		params.ParentVlanID = &v
+	}
+	if d.HasChange("tags") {
+		v := d.Get("tags").(string)
+		params.Tags = &v
 	}
 
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
 	resp, err := client.IPam.PostIPAMsubnets(params)
 
 	if err != nil {
@@ -403,21 +550,175 @@ func resourceIpamSubnetUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error updating subnet. %s", msg[0])
 	}
 
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "subnet", id)); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
 	d.SetId(string(msg[1]))
 
 	return resourceIpamSubnetRead(ctx, d, meta)
 }
 
 func resourceIpamSubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	if abandonOnDestroy(d, meta, "subnet") {
+		d.SetId("")
+		return nil
+	}
+
+	if diags := checkDeletionProtection(d, "subnet"); diags != nil {
+		return diags
+	}
 
-	params := ipam.NewDeleteIPAMsubnetsParams()
 	id := d.Id()
 	i, err := strconv.Atoi(id)
 	if err != nil {
 		return diag.Errorf("error getting subnetid. %s", err)
 	}
-	params.SetSubnetID(int64(i))
+
+	if d.Get("force_delete").(bool) {
+		if diags := ipamSubnetsDeleteContents(ctx, meta, id); diags != nil {
+			return diags
+		}
+	} else {
+		subnets, ips, diags := ipamSubnetsContents(ctx, meta, id)
+		if diags != nil {
+			return diags
+		}
+
+		if len(subnets) > 0 || len(ips) > 0 {
+			return diag.Errorf("error subnet %s still has %d child subnets and %d allocated IPs, set force_delete to delete it anyway.", id, len(subnets), len(ips))
+		}
+	}
+
+	if diags := ipamSubnetsDeleteID(ctx, meta, int64(i)); diags != nil {
+		return diags
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// ipamSubnetsChildren returns the direct child subnets of a subnet, reading
+// every page of them.
+func ipamSubnetsChildren(ctx context.Context, meta interface{}, subnet_id string) ([]*models.IPAMsubnets, error) {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMsubnetsParamsWithContext(ctx)
+	params.SetParentSubnetID(&subnet_id)
+
+	subnets := make([]*models.IPAMsubnets, 0)
+
+	for {
+		page, total, err := getIPAMsubnetsPage(ctx, client, params, len(subnets))
+
+		if err != nil {
+			return nil, err
+		}
+
+		subnets = append(subnets, page...)
+
+		if len(page) == 0 || len(subnets) >= total {
+			return subnets, nil
+		}
+	}
+}
+
+// ipamSubnetsContents returns the direct child subnets and the allocated IPs
+// of a subnet, reading every page of them.
+func ipamSubnetsContents(ctx context.Context, meta interface{}, subnet_id string) ([]*models.IPAMsubnets, []*models.IPAMips, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	subnets, err := ipamSubnetsChildren(ctx, meta, subnet_id)
+
+	if err != nil {
+		return nil, nil, diag.Errorf("error reading child subnets. %s", err)
+	}
+
+	ip_params := ipam.NewGetIPAMIpsParamsWithContext(ctx)
+	ip_params.SetSubnetID(&subnet_id)
+	limit := strconv.Itoa(ipamPageSize)
+	ip_params.SetLimit(&limit)
+
+	ips := make([]*models.IPAMips, 0)
+
+	for read := 0; ; {
+		offset := strconv.Itoa(read)
+		ip_params.SetOffset(&offset)
+
+		resp, err := client.IPam.GetIPAMIps(ip_params)
+
+		if err != nil {
+			return nil, nil, diag.Errorf("error reading subnet IPs. %s", err)
+		}
+
+		for _, ip := range resp.Payload.Ips {
+			if available, ok := parseYesNo(ip.Available); ok && available {
+				continue
+			}
+			ips = append(ips, ip)
+		}
+
+		read += len(resp.Payload.Ips)
+
+		if len(resp.Payload.Ips) == 0 || read >= intOrZero(resp.Payload.TotalCount) {
+			break
+		}
+	}
+
+	return subnets, ips, nil
+}
+
+// ipamSubnetsDeleteContents deletes everything under a subnet depth-first,
+// the IPs of each child subnet before the child itself.
+func ipamSubnetsDeleteContents(ctx context.Context, meta interface{}, subnet_id string) diag.Diagnostics {
+	subnets, ips, diags := ipamSubnetsContents(ctx, meta, subnet_id)
+	if diags != nil {
+		return diags
+	}
+
+	log.Printf("[INFO] force deleting subnet %s: %d child subnets, %d IPs", subnet_id, len(subnets), len(ips))
+
+	for _, subnet := range subnets {
+		child_id, err := subnet.SubnetID.(json.Number).Int64()
+		if err != nil {
+			return diag.Errorf("error reading child subnet ID. %s", err)
+		}
+
+		if diags := ipamSubnetsDeleteContents(ctx, meta, strconv.FormatInt(child_id, 10)); diags != nil {
+			return diags
+		}
+
+		log.Printf("[INFO] deleting child subnet %d of subnet %s", child_id, subnet_id)
+
+		if diags := ipamSubnetsDeleteID(ctx, meta, child_id); diags != nil {
+			return diags
+		}
+	}
+
+	for _, ip := range ips {
+		ip_id, err := strconv.ParseInt(fmt.Sprint(ip.ID), 10, 64)
+		if err != nil {
+			return diag.Errorf("error reading IP ID. %s", err)
+		}
+
+		log.Printf("[DEBUG] deleting IP %v (%d) of subnet %s", ip.IP, ip_id, subnet_id)
+
+		if diags := ipamIPsDeleteID(ctx, meta, ip_id); diags != nil {
+			return diags
+		}
+	}
+
+	return nil
+}
+
+func ipamSubnetsDeleteID(ctx context.Context, meta interface{}, subnet_id int64) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewDeleteIPAMsubnetsParamsWithContext(ctx)
+	params.SetSubnetID(subnet_id)
 
 	resp, err := client.IPam.DeleteIPAMsubnets(params)
 
@@ -436,15 +737,11 @@ func resourceIpamSubnetDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error deleting IPAM subnet.")
 	}
 
-	d.SetId("")
-
 	return nil
 }
 
 func setIpamSubnet(d *schema.ResourceData, resp *models.IPAMsubnets) {
-	if v, ok := resp.CustomerID.(json.Number); ok {
-		d.Set("customer_id", v.String())
-	}
+	d.Set("customer_id", stringOrNumber(resp.CustomerID))
 	if v, ok := resp.MaskBits.(json.Number); ok {
 		d.Set("mask_bits", v.String())
 	}
@@ -454,9 +751,7 @@ func setIpamSubnet(d *schema.ResourceData, resp *models.IPAMsubnets) {
 	if v, ok := resp.Network.(string); ok {
 		d.Set("network", v)
 	}
-	if v, ok := resp.ParentVlanID.(json.Number); ok {
-		d.Set("parent_vlan_id", v.String())
-	}
+	d.Set("parent_vlan_id", stringOrNumber(resp.ParentVlanID))
 	if v, ok := resp.ParentVlanName.(string); ok {
 		d.Set("parent_vlan_name", v)
 	}
@@ -466,7 +761,5 @@ func setIpamSubnet(d *schema.ResourceData, resp *models.IPAMsubnets) {
 	if v, ok := resp.SubnetID.(json.Number); ok {
 		d.Set("subnet_id", v.String())
 	}
-	if v := resp.Tags; v != nil {
-		d.Set("tags", strings.Join(v, ","))
-	}
+	d.Set("tags", strings.Join(resp.Tags, ","))
 }
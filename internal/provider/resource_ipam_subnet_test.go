@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/poroping/libdevice42/client"
+)
+
+// testIpamServer fakes the subnet, IP and vlan endpoints of Device42,
+// answering one object per page so every listing has to page through. Deletes
+// are recorded.
+type testIpamServer struct {
+	children map[string][]int
+	networks map[int]string
+	ips      map[string][]map[string]interface{}
+	vlans    []interface{}
+	deleted  []string
+}
+
+func (s *testIpamServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	w.Header().Set("Content-Type", "application/json")
+
+	page := func(key string, l []interface{}) map[string]interface{} {
+		body := map[string]interface{}{key: []interface{}{}, "total_count": len(l)}
+		if offset < len(l) {
+			body[key] = l[offset : offset+1]
+		}
+		return body
+	}
+
+	var body interface{}
+	switch {
+	case r.Method == "DELETE":
+		s.deleted = append(s.deleted, strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/1.0/"), "/"))
+		body = map[string]interface{}{"deleted": "true"}
+	case r.URL.Path == "/api/1.0/subnets/":
+		l := make([]interface{}, 0)
+		for _, id := range s.children[q.Get("parent_subnet_id")] {
+			subnet := map[string]interface{}{"subnet_id": id}
+			if n, ok := s.networks[id]; ok {
+				parts := strings.SplitN(n, "/", 2)
+				subnet["network"], subnet["mask_bits"] = parts[0], parts[1]
+			}
+			l = append(l, subnet)
+		}
+		body = page("subnets", l)
+	case r.URL.Path == "/api/1.0/ips/":
+		l := make([]interface{}, 0)
+		for _, ip := range s.ips[q.Get("subnet_id")] {
+			l = append(l, ip)
+		}
+		body = page("ips", l)
+	case r.URL.Path == "/api/1.0/vlans/":
+		body = page("vlans", s.vlans)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	json.NewEncoder(w).Encode(body)
+}
+
+func TestIpamSubnetsDeleteContents(t *testing.T) {
+	s := &testIpamServer{
+		children: map[string][]int{"1": {2, 3}, "2": {4}},
+		ips: map[string][]map[string]interface{}{
+			"1": {
+				{"id": 10, "ip": "10.0.0.1", "available": "no"},
+				{"id": 11, "ip": "10.0.0.2", "available": "yes"},
+			},
+			"4": {
+				{"id": 12, "ip": "10.0.4.1", "available": "no"},
+			},
+		},
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	meta := &apiClient{
+		Device42: client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/",
+			Schemes:  []string{"http"},
+		}),
+	}
+
+	subnets, ips, diags := ipamSubnetsContents(context.Background(), meta, "1")
+	if diags != nil {
+		t.Fatalf("ipamSubnetsContents: %v", diags)
+	}
+	if len(subnets) != 2 || len(ips) != 1 {
+		t.Errorf("got %d subnets and %d IPs, want 2 and 1", len(subnets), len(ips))
+	}
+
+	if diags := ipamSubnetsDeleteContents(context.Background(), meta, "1"); diags != nil {
+		t.Fatalf("ipamSubnetsDeleteContents: %v", diags)
+	}
+
+	// depth-first, the available IP 11 is left alone.
+	want := []string{"ips/12", "subnets/4", "subnets/2", "subnets/3", "ips/10"}
+	if !reflect.DeepEqual(s.deleted, want) {
+		t.Errorf("deleted %v, want %v", s.deleted, want)
+	}
+}
+
+func TestIpamSubnetsSiblingOverlap(t *testing.T) {
+	// one subnet per page, 3 overlaps 5 and is only on the second page.
+	s := &testIpamServer{
+		children: map[string][]int{"1": {2, 3, 5}},
+		networks: map[int]string{2: "10.0.1.0/24", 3: "10.0.0.0/25", 5: "10.0.0.0/24"},
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	meta := &apiClient{
+		Device42: client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/",
+			Schemes:  []string{"http"},
+		}),
+	}
+
+	for _, tt := range []struct {
+		subnet_id int64
+		network   string
+		overlap   bool
+	}{
+		{5, "10.0.0.0/24", true},
+		{3, "10.0.0.0/25", false},
+		{2, "10.0.1.0/24", false},
+	} {
+		t.Run(fmt.Sprint(tt.subnet_id), func(t *testing.T) {
+			n := strings.Split(tt.network, "/")
+			overlap, diags := ipamSubnetsSiblingOverlap(context.Background(), meta, "1", tt.subnet_id, n[0], n[1])
+			if diags != nil {
+				t.Fatalf("ipamSubnetsSiblingOverlap: %v", diags)
+			}
+			if overlap != tt.overlap {
+				t.Errorf("overlap = %t, want %t", overlap, tt.overlap)
+			}
+		})
+	}
+}
+
+func TestAccResourceIpamSubnet_clearName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIpamSubnetConfig(`name = "TF-ACC-TEST-SUBNET"
+  tags = "TF-ACC-TEST"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "name", "TF-ACC-TEST-SUBNET"),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "tags", "TF-ACC-TEST"),
+				),
+			},
+			{
+				Config: testAccResourceIpamSubnetConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "name", ""),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "tags", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceIpamSubnet_customer(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIpamSubnetCustomerConfig(name, "customer = device42_customer.test.name"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "customer", name),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "customer_id", ""),
+					resource.TestCheckResourceAttrPair("device42_ipam_subnet.test", "resolved_customer_id", "device42_customer.test", "customer_id"),
+				),
+			},
+			{
+				Config: testAccResourceIpamSubnetCustomerConfig(name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "customer", ""),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "customer_id", ""),
+					resource.TestCheckResourceAttr("device42_ipam_subnet.test", "resolved_customer_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIpamSubnetConfig(extra string) string {
+	return fmt.Sprintf(`
+resource "device42_ipam_subnet" "test" {
+  mask_bits = "29"
+  network   = "10.254.0.8"
+  %s
+}
+`, extra)
+}
+
+func testAccResourceIpamSubnetCustomerConfig(name, extra string) string {
+	return fmt.Sprintf(`
+resource "device42_customer" "test" {
+  name = "%s"
+}
+`, name) + testAccResourceIpamSubnetConfig(extra)
+}
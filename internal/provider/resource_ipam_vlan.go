@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -10,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/poroping/libdevice42/client"
+	"github.com/poroping/libdevice42/client/devices"
 	ipam "github.com/poroping/libdevice42/client/ip_a_m"
 	"github.com/poroping/libdevice42/models"
 
@@ -28,11 +29,23 @@ func resourceIpamVlan() *schema.Resource {
 
 		Importer: nil,
 
+		Timeouts: resourceTimeouts(),
+
 		Schema: map[string]*schema.Schema{
+			"custom_fields": customFieldsSchema(),
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
 			"name": {
 				Description: "Name.",
 				Type:        schema.TypeString,
-				Computed:    true,
+				Optional:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
 				Optional:    true,
 			},
 			"number": {
@@ -41,27 +54,81 @@ func resourceIpamVlan() *schema.Resource {
 				Computed:    true,
 				Optional:    true,
 			},
+			"switches": {
+				Description:      "Comma separated switch device IDs or names the VLAN is linked to. Left unset, VLANs created with a `match` block are linked to the matched switches and the links aren't managed.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffFakeListEqual,
+			},
+			"switch_ids": {
+				Description: "Comma separated device IDs of the switches the VLAN is linked to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"tags": {
 				Description:      "Tags.",
 				Type:             schema.TypeString,
-				Computed:         true,
 				Optional:         true,
 				DiffSuppressFunc: diffFakeListEqual,
 			},
-			// "tags_or": {
-			// 	Description: "Tags (OR).",
-			// 	Type:        schema.TypeString,
-			// 	Optional:    true,
-			// },
 			"tags_exist": {
-				Description: "Tags (AND) - used for filtering with `check_if_exists`.",
-				Type:        schema.TypeString,
-				Optional:    true,
+				Description:   "Tags (AND) - used for filtering with `check_if_exists`.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Deprecated:    "Use `match.tags_and` (together with `match.number`) instead.",
+				ConflictsWith: []string{"match"},
 			},
 			"tags_range": {
-				Description: "Tags (AND) - used for filtering with `create_within_range`.",
-				Type:        schema.TypeString,
+				Description:   "Tags (AND) - used for filtering with `create_within_range`.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Deprecated:    "Use `match.tags_and` instead.",
+				ConflictsWith: []string{"match"},
+			},
+			"match": {
+				Description: "Filter for the VLANs considered by `check_if_exists`/`on_existing` (the VLAN to adopt) and `create_within_range` (the VLANs in use). All criteria set must match. VLANs created with either option are linked to the matched switches, unless `switches` is set, and get the matched domain.",
+				Type:        schema.TypeList,
 				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"building": {
+							Description: "Building name - VLANs on switches in this building.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"domain": {
+							Description: "VLANs with this value in the `domain` custom field.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"name": {
+							Description: "VLAN name.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"number": {
+							Description: "VLAN number. The resource `number` takes precedence when it is set.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"switch": {
+							Description: "Comma separated switch device IDs or names - VLANs on any of these switches.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"tags_and": {
+							Description: "Comma separated tags - VLANs with all of these tags.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"tags_or": {
+							Description: "Comma separated tags - VLANs with any of these tags.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
 			},
 			"vlan_id": {
 				Description: "VLAN ID.",
@@ -70,36 +137,65 @@ func resourceIpamVlan() *schema.Resource {
 				Optional:    true,
 			},
 			"create_within_range": {
-				Description:   "Use to create vlan from a range of vlans.",
+				Description:   "Use to create vlan from a range of vlans. Comma separated list of VLANs and inclusive ranges, prefix with `!` to exclude, e.g. `100-199,300,!150`.",
 				Type:          schema.TypeString,
 				Optional:      true,
-				RequiredWith:  []string{"tags_range", "name"},
+				RequiredWith:  []string{"name"},
 				ConflictsWith: []string{"number"},
-				ValidateFunc:  validation.StringMatch(validateRegExpVlanRange(), "Provide valid VLAN range"),
+				ValidateFunc:  validateVlanRange,
+			},
+			"allocation_strategy": {
+				Description:  "How to pick a free VLAN with `create_within_range`. One of `lowest`, `highest`, `random` or `round-robin` (the first free VLAN after the highest one in use, wrapping around).",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      vlanAllocationLowest,
+				ValidateFunc: validation.StringInSlice(vlanAllocationStrategies, false),
 			},
 			"check_if_exists": {
-				Description: "Use to check if vlan exists already.",
+				Description: "Use to check if vlan exists already. Same as `on_existing = \"adopt\"`, which takes precedence.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 			},
+			"on_existing":         onExistingSchema("vlan"),
+			"adopted":             adoptedSchema("vlan"),
+			"deletion_policy":     deletionPolicySchema("vlan"),
+			"deletion_protection": deletionProtectionSchema("vlan"),
 		},
 	}
 }
 
 func resourceIpamVlanCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
 	if v, ok := d.GetOk("vlan_id"); ok {
-		if s, ok := v.(string); ok {
-			d.SetId(s)
-			return resourceIpamVlanUpdate(ctx, d, meta)
-		}
+		return adoptExisting(ctx, d, meta, "vlan", v.(string), resourceIpamVlanUpdate, resourceIpamVlanRead)
 	}
 
-	params := ipam.NewPostIPAMvlansParams()
+	params := ipam.NewPostIPAMvlansParamsWithContext(ctx)
+
+	_, within_range := d.GetOk("create_within_range")
+	check_existing := onExistingPolicy(d) != ""
 
-	if d.Get("check_if_exists").(bool) {
+	scope := &ipamVlanScope{}
+
+	if check_existing || within_range {
+		match := ipamVlanMatchFromConfig(d, within_range)
+
+		// serialize check/pick/create within the same range and scope so
+		// parallel applies can't be handed the same VLAN.
+		lock_key := ipamVlanLockKey(d, match)
+		ipamMutexKV.Lock(lock_key)
+		defer ipamMutexKV.Unlock(lock_key)
+
+		var diags diag.Diagnostics
+		scope, diags = ipamVlanScopeFromMatch(ctx, meta, match)
+		if diags != nil {
+			return diags
+		}
+	}
+
+	if check_existing {
 		err, vlan_id := ipamVlansCheckExist(ctx, d, meta)
 
 		if err != nil {
@@ -107,22 +203,53 @@ func resourceIpamVlanCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 
 		if vlan_id != nil {
-			d.SetId(*vlan_id)
-			return resourceIpamVlanUpdate(ctx, d, meta)
+			return adoptExisting(ctx, d, meta, "vlan", *vlan_id, resourceIpamVlanUpdate, resourceIpamVlanRead)
 		}
 	}
 
-	if _, ok := d.GetOk("create_within_range"); ok {
-		next_vlan, _ := ipamVlanFromRange(ctx, d, meta)
+	// configured switches replace the matched ones.
+	switch_ids := scope.switches
+	if v, ok := d.GetOk("switches"); ok {
+		ids, diags := resolveSwitchIDs(ctx, meta, v.(string))
+		if diags != nil {
+			return diags
+		}
+		switch_ids = make(map[string]bool)
+		for _, id := range ids {
+			switch_ids[id] = true
+		}
+	}
+
+	if ids := joinKeys(switch_ids); ids != "" {
+		params.SwitchID = &ids
+	}
+
+	if within_range {
+		next_vlan, diags := ipamVlanFromRange(ctx, d, meta)
+		if diags != nil {
+			return diags
+		}
 		params.Number = strconv.Itoa(*next_vlan)
 	}
 
+	if v, ok := d.GetOk("description"); ok {
+		if s, ok := v.(string); ok {
+			params.Description = &s
+		}
+	}
+
 	if v, ok := d.GetOk("name"); ok {
 		if s, ok := v.(string); ok {
 			params.Name = &s
 		}
 	}
 
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+
 	if v, ok := d.GetOk("number"); ok {
 		if s, ok := v.(string); ok {
 			params.Number = s
@@ -150,103 +277,304 @@ func resourceIpamVlanCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(string(msg[1]))
 
+	if scope.domain != "" {
+		params := formParams{
+			"key":   ipamVlanDomainField,
+			"value": scope.domain,
+		}
+
+		if diags := setCustomField(ctx, meta, "switch_vlan", d.Id(), params); diags != nil {
+			return diags
+		}
+	}
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "switch_vlan", d.Id())); diags != nil {
+		return diags
+	}
+
 	return resourceIpamVlanRead(ctx, d, meta)
 }
 
+func ipamVlanLockKey(d *schema.ResourceData, m *ipamVlanMatch) string {
+	return fmt.Sprintf("device42_ipam_vlan/range/%s/%+v", d.Get("create_within_range").(string), *m)
+}
+
 func ipamVlanFromRange(ctx context.Context, d *schema.ResourceData, meta interface{}) (*int, diag.Diagnostics) {
-	client := meta.(*client.Device42)
+	vlan_range, err := parseVlanRange(d.Get("create_within_range").(string))
 
-	params := ipam.NewGetIPAMvlansParams()
+	if err != nil {
+		return nil, diag.Errorf("error parsing create_within_range. %s", err)
+	}
 
-	if v, ok := d.GetOk("tags_range"); ok {
-		if s, ok := v.(string); ok {
-			params.TagsAnd = &s
-		}
+	// vlans matching the filter are considered used
+
+	vlans, diags := ipamVlansMatching(ctx, meta, ipamVlanMatchFromConfig(d, true))
+	if diags != nil {
+		return nil, diags
 	}
 
-	vlan_range := make([]int, 0)
+	used_vlans := make([]int, 0, len(vlans))
 
-	if v, ok := d.GetOk("create_within_range"); ok {
-		if s, ok := v.(string); ok {
-			mins := strings.Split(s, "-")[0]
-			maxs := strings.Split(s, "-")[1]
-			min, _ := strconv.Atoi(mins)
-			max, _ := strconv.Atoi(maxs)
-			for i := min; i < max; i++ {
-				vlan_range = append(vlan_range, i)
-			}
+	for _, vlan := range vlans {
+		num, _ := vlan.Number.(json.Number).Int64()
+		used_vlans = append(used_vlans, int(num))
+	}
+
+	sort.Ints(used_vlans)
+
+	free_vlans := funk.Subtract(vlan_range, used_vlans).([]int)
+	used_in_range := funk.Subtract(vlan_range, free_vlans).([]int)
+
+	if len(free_vlans) == 0 {
+		return nil, diag.Errorf("no free VLAN in range %s (%d used).", d.Get("create_within_range").(string), len(used_in_range))
+	}
+
+	next_vlan := pickVlan(free_vlans, used_in_range, d.Get("allocation_strategy").(string))
+
+	return &next_vlan, nil
+}
+
+func ipamVlansCheckExist(ctx context.Context, d *schema.ResourceData, meta interface{}) (diag.Diagnostics, *string) {
+	vlans, diags := ipamVlansMatching(ctx, meta, ipamVlanMatchFromConfig(d, false))
+	if diags != nil {
+		return diags, nil
+	}
+
+	if len(vlans) == 0 {
+		return nil, nil
+	}
+
+	if len(vlans) > 1 {
+		return diag.Errorf("error multiple vlans found, filter better."), nil
+	}
+
+	vlan_id := vlans[0].VlanID.(json.Number).String()
+
+	return nil, &vlan_id
+}
+
+// ipamVlanMatch is the `match` block.
+type ipamVlanMatch struct {
+	building string
+	domain   string
+	name     string
+	number   string
+	switches string
+	tagsAnd  string
+	tagsOr   string
+}
+
+// ipamVlanMatchFromConfig returns the `match` block, or when it isn't set the
+// filter the deprecated attributes stood for: `number` and `tags_exist` for
+// `check_if_exists`, `tags_range` for `create_within_range`.
+func ipamVlanMatchFromConfig(d *schema.ResourceData, within_range bool) *ipamVlanMatch {
+	if v, ok := d.GetOk("match"); ok {
+		m := &ipamVlanMatch{}
+		l := v.([]interface{})
+		if len(l) == 0 || l[0] == nil {
+			return m
+		}
+		b := l[0].(map[string]interface{})
+		m.building = b["building"].(string)
+		m.domain = b["domain"].(string)
+		m.name = b["name"].(string)
+		m.number = b["number"].(string)
+		m.switches = b["switch"].(string)
+		m.tagsAnd = b["tags_and"].(string)
+		m.tagsOr = b["tags_or"].(string)
+		// the VLAN to adopt has to have the configured number, or the next
+		// update renumbers whatever VLAN matched.
+		if n := d.Get("number").(string); n != "" && !within_range {
+			m.number = n
 		}
+		return m
 	}
 
-	if len(vlan_range) == 0 {
-		return nil, diag.Errorf("no vlans in range.")
+	if within_range {
+		return &ipamVlanMatch{
+			tagsAnd: d.Get("tags_range").(string),
+		}
 	}
 
-	// find used vlans based on tag
+	return &ipamVlanMatch{
+		number:  d.Get("number").(string),
+		tagsAnd: d.Get("tags_exist").(string),
+	}
+}
 
-	used_vlans := make([]int, 0)
+// ipamVlansMatching returns the vlans matching m. Tags and number are filtered
+// by the API, the rest here.
+func ipamVlansMatching(ctx context.Context, meta interface{}, m *ipamVlanMatch) ([]*ipamVlan, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
 
-	resp, err := client.IPam.GetIPAMvlans(params)
+	params := ipam.NewGetIPAMvlansParamsWithContext(ctx)
+
+	if m.number != "" {
+		params.Number = &m.number
+	}
+	if m.tagsAnd != "" {
+		params.TagsAnd = &m.tagsAnd
+	}
+	if m.tagsOr != "" {
+		params.Tags = &m.tagsOr
+	}
+
+	scope, diags := ipamVlanScopeFromMatch(ctx, meta, m)
+	if diags != nil {
+		return nil, diags
+	}
+
+	resp, err := getIPAMvlans(ctx, client, params)
 
 	if err != nil {
 		return nil, diag.Errorf("error reading vlans. %s", err)
 	}
 
-	vlans := resp.Payload.Vlans
+	vlans := make([]*ipamVlan, 0)
 
-	for _, vlan := range vlans {
-		num, _ := vlan.Number.(json.Number).Int64()
-		used_vlans = append(used_vlans, int(num))
-		sort.Ints(used_vlans)
+	for _, vlan := range resp {
+		if m.name != "" {
+			if name, _ := vlan.Name.(string); name != m.name {
+				continue
+			}
+		}
+		if scope.matches(vlan) {
+			vlans = append(vlans, vlan)
+		}
 	}
 
-	next_vlan := funk.Subtract(vlan_range, used_vlans).([]int)[0]
+	return vlans, nil
+}
 
-	return &next_vlan, nil
+// ipamVlanDomainField is the vlan custom field used by `match.domain`.
+const ipamVlanDomainField = "domain"
+
+// ipamVlanScope narrows vlans to an L2 domain. VLAN numbers are only unique
+// per domain, which Device42 models as the switches a vlan is linked to or a
+// custom field. The vlans endpoint can't filter on either so it's done here.
+type ipamVlanScope struct {
+	// switch device IDs, nil when not scoped by switch/building
+	switches map[string]bool
+	domain   string
 }
 
-func ipamVlansCheckExist(ctx context.Context, d *schema.ResourceData, meta interface{}) (diag.Diagnostics, *string) {
-	client := meta.(*client.Device42)
+func ipamVlanScopeFromMatch(ctx context.Context, meta interface{}, m *ipamVlanMatch) (*ipamVlanScope, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMvlansParams()
+	scope := &ipamVlanScope{
+		domain: m.domain,
+	}
 
-	if v, ok := d.GetOk("number"); ok {
-		if s, ok := v.(string); ok {
-			params.Number = &s
+	if m.switches != "" {
+		ids, diags := resolveSwitchIDs(ctx, meta, m.switches)
+		if diags != nil {
+			return nil, diags
 		}
-	}
 
-	if v, ok := d.GetOk("tags_exist"); ok {
-		if s, ok := v.(string); ok {
-			params.TagsAnd = &s
+		scope.switches = make(map[string]bool)
+
+		for _, id := range ids {
+			scope.switches[id] = true
 		}
 	}
 
-	resp, err := client.IPam.GetIPAMvlans(params)
+	if m.building != "" {
+		building := m.building
+		is_it_switch := "yes"
 
-	if err != nil {
-		return diag.Errorf("error reading response. %s", err), nil
+		params := devices.NewGetDevicesParamsWithContext(ctx)
+		params.SetBuilding(&building)
+		params.SetIsItSwitch(&is_it_switch)
+
+		resp, err := client.Devices.GetDevices(params, nil)
+
+		if err != nil {
+			return nil, diag.Errorf("error reading switches in building %s. %s", building, err)
+		}
+
+		if len(resp.Payload.Devices) == 0 {
+			return nil, diag.Errorf("error no switches found in building %s.", building)
+		}
+
+		if scope.switches == nil {
+			scope.switches = make(map[string]bool)
+		}
+
+		for _, device := range resp.Payload.Devices {
+			scope.switches[fmt.Sprint(device.DeviceID)] = true
+		}
 	}
 
-	vlans := resp.Payload.Vlans
+	return scope, nil
+}
 
-	if len(vlans) == 0 {
-		return nil, nil
+func (s *ipamVlanScope) matches(vlan *ipamVlan) bool {
+	if s.domain != "" {
+		if v, _ := customFieldValue(vlan.CustomFields, ipamVlanDomainField); v != s.domain {
+			return false
+		}
 	}
 
-	if len(vlans) > 1 {
-		return diag.Errorf("error multiple vlans found, filter better."), nil
+	if s.switches != nil {
+		for _, sw := range vlan.Switches {
+			if sw != nil && s.switches[fmt.Sprint(sw.DeviceID)] {
+				return true
+			}
+		}
+		return false
 	}
 
-	vlan_id := resp.Payload.Vlans[0].VlanID.(json.Number).String()
+	return true
+}
 
-	return nil, &vlan_id
+// resolveSwitchIDs turns a comma separated list of switch device IDs or names
+// into device IDs.
+func resolveSwitchIDs(ctx context.Context, meta interface{}, refs string) ([]string, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	ids := make([]string, 0)
+
+	for _, sw := range deleteEmpty(strings.Split(refs, ",")) {
+		sw = strings.TrimSpace(sw)
+
+		if _, err := strconv.Atoi(sw); err == nil {
+			ids = append(ids, sw)
+			continue
+		}
+
+		params := devices.NewGetDevicesParamsWithContext(ctx)
+		params.SetName(&sw)
+
+		resp, err := client.Devices.GetDevices(params, nil)
+
+		if err != nil {
+			return nil, diag.Errorf("error reading switch %s. %s", sw, err)
+		}
+
+		if len(resp.Payload.Devices) != 1 {
+			return nil, diag.Errorf("error switch %s not found or not unique.", sw)
+		}
+
+		ids = append(ids, fmt.Sprint(resp.Payload.Devices[0].DeviceID))
+	}
+
+	return ids, nil
+}
+
+// joinKeys returns the sorted keys of a set as a comma separated list.
+func joinKeys(m map[string]bool) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
 }
 
 func resourceIpamVlanRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewGetIPAMvlansIDParams()
+	params := ipam.NewGetIPAMvlansIDParamsWithContext(ctx)
 	id := d.Id()
 	i, err := strconv.Atoi(id)
 	if err != nil {
@@ -262,36 +590,94 @@ func resourceIpamVlanRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	setIpamVlan(d, resp.Payload)
 
+	if v, ok := d.GetOk("custom_fields"); ok {
+		vlan_id := id
+		params := ipam.NewGetIPAMvlansParamsWithContext(ctx)
+		params.SetVlanID(&vlan_id)
+
+		vlans, err := getIPAMvlans(ctx, client, params)
+
+		if err != nil {
+			return diag.Errorf("error reading IPAM vlan custom fields. %s", err)
+		}
+
+		if len(vlans) == 1 {
+			d.Set("custom_fields", flattenCustomFields(vlans[0].CustomFields, v.(map[string]interface{})))
+		}
+	}
+
 	return nil
 }
 
+func ipamVlanCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	params := ipam.NewGetIPAMvlansParamsWithContext(ctx)
+	params.SetVlanID(&id)
+
+	vlans, err := getIPAMvlans(ctx, meta.(*apiClient).Device42, params)
+
+	if err != nil {
+		return nil, diag.Errorf("error reading IPAM vlan custom fields. %s", err)
+	}
+
+	if len(vlans) != 1 {
+		return nil, diag.Errorf("error vlan %s not found.", id)
+	}
+
+	return vlans[0].CustomFields, nil
+}
+
 func resourceIpamVlanUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	if adoptedReadonly(d) {
+		return diag.Errorf("error vlan %s is adopted read-only, set on_existing = \"adopt\" to manage it.", d.Id())
+	}
+
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewPutIPAMvlansParams()
+	params := ipam.NewPutIPAMvlansParamsWithContext(ctx)
 	id := d.Id()
 	i, err := strconv.Atoi(id)
 	if err != nil {
 		return diag.Errorf("error getting vlanid. %s", err)
 	}
 	params.SetID(int64(i))
+	cleared := changedToEmpty(d, "description", "name", "notes", "tags")
+	if d.HasChange("switches") && d.Get("switches").(string) == "" {
+		cleared = append(cleared, "switch_id")
+	}
+	params.SetContext(withClearedFormParams(ctx, cleared...))
 
-	if v, ok := d.GetOk("name"); ok {
-		if s, ok := v.(string); ok {
-			params.Name = &s
-		}
+	if d.HasChange("description") {
+		v := d.Get("description").(string)
+		params.Description = &v
 	}
-	if v, ok := d.GetOk("number"); ok {
-		if s, ok := v.(string); ok {
-			params.Number = &s
-		}
+	if d.HasChange("name") {
+		v := d.Get("name").(string)
+		params.Name = &v
 	}
-	if v, ok := d.GetOk("tags"); ok {
-		if s, ok := v.(string); ok {
-			params.Tags = &s
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+	if d.HasChange("switches") {
+		ids, diags := resolveSwitchIDs(ctx, meta, d.Get("switches").(string))
+		if diags != nil {
+			return diags
 		}
+		v := strings.Join(ids, ",")
+		params.SwitchID = &v
+	}
+	if d.HasChange("number") {
+		v := d.Get("number").(string)
+		params.Number = &v
+	}
+	if d.HasChange("tags") {
+		v := d.Get("tags").(string)
+		params.Tags = &v
 	}
 
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
 	resp, err := client.IPam.PutIPAMvlans(params)
 
 	if err != nil {
@@ -305,15 +691,30 @@ func resourceIpamVlanUpdate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("error updating vlan. %s", msg[0])
 	}
 
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "switch_vlan", id)); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
 	d.SetId(string(msg[1]))
 
 	return resourceIpamVlanRead(ctx, d, meta)
 }
 
 func resourceIpamVlanDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client.Device42)
+	if abandonOnDestroy(d, meta, "vlan") {
+		d.SetId("")
+		return nil
+	}
+
+	if diags := checkDeletionProtection(d, "vlan"); diags != nil {
+		return diags
+	}
+
+	client := meta.(*apiClient).Device42
 
-	params := ipam.NewDeleteIPAMvlansParams()
+	params := ipam.NewDeleteIPAMvlansParamsWithContext(ctx)
 	id := d.Id()
 	i, err := strconv.Atoi(id)
 	if err != nil {
@@ -344,16 +745,47 @@ func resourceIpamVlanDelete(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func setIpamVlan(d *schema.ResourceData, resp *models.IPAMvlans) {
-	if v, ok := resp.Name.(string); ok {
-		d.Set("name", v)
-	}
+	d.Set("description", stringOrEmpty(resp.Description))
+	d.Set("name", stringOrEmpty(resp.Name))
+	d.Set("notes", stringOrEmpty(resp.Notes))
+	// the links are only managed through `switches` when it is set.
+	switches, switch_ids := flattenIpamVlanSwitches(resp.Switches, d.Get("switches").(string))
+	if d.Get("switches").(string) != "" {
+		d.Set("switches", switches)
+	}
+	d.Set("switch_ids", switch_ids)
 	if v, ok := resp.Number.(json.Number); ok {
 		d.Set("number", v.String())
 	}
-	if v := resp.Tags; v != nil {
-		d.Set("tags", strings.Join(v, ","))
-	}
+	d.Set("tags", strings.Join(resp.Tags, ","))
 	if v, ok := resp.VlanID.(json.Number); ok {
 		d.Set("vlan_id", v.String())
 	}
 }
+
+// flattenIpamVlanSwitches returns the linked switches, referenced by name where
+// the configuration used names and by device ID otherwise, and their IDs.
+func flattenIpamVlanSwitches(switches []*models.IPAMvlansSwitchesItems0, refs string) (string, string) {
+	by_name := make(map[string]bool)
+	for _, ref := range strings.Split(refs, ",") {
+		by_name[strings.TrimSpace(ref)] = true
+	}
+
+	names := make([]string, 0, len(switches))
+	ids := make([]string, 0, len(switches))
+
+	for _, sw := range switches {
+		if sw == nil {
+			continue
+		}
+		id := fmt.Sprint(sw.DeviceID)
+		ids = append(ids, id)
+		if name, ok := sw.Name.(string); ok && by_name[name] {
+			names = append(names, name)
+		} else {
+			names = append(names, id)
+		}
+	}
+
+	return strings.Join(names, ","), strings.Join(ids, ",")
+}
@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client"
+	"github.com/poroping/libdevice42/models"
+)
+
+func TestAccResourceIpamVlan_clearTags(t *testing.T) {
+	number := acctest.RandIntRange(3000, 4000)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceIpamVlanConfig(number, `tags = "TF-ACC-TEST"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_vlan.test", "tags", "TF-ACC-TEST"),
+				),
+			},
+			{
+				Config: testAccResourceIpamVlanConfig(number, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_ipam_vlan.test", "tags", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccResourceIpamVlanConfig(number int, extra string) string {
+	return fmt.Sprintf(`
+resource "device42_ipam_vlan" "test" {
+  name   = "TF-ACC-TEST-VLAN"
+  number = "%d"
+  %s
+}
+`, number, extra)
+}
+
+func TestIpamVlanScopeMatches(t *testing.T) {
+	vlan := &ipamVlan{
+		IPAMvlans: models.IPAMvlans{
+			Switches: []*models.IPAMvlansSwitchesItems0{
+				{DeviceID: json.Number("10")},
+				{DeviceID: json.Number("11")},
+			},
+		},
+		CustomFields: []*customField{
+			{Key: "domain", Value: "DC-01"},
+		},
+	}
+
+	var tests = []struct {
+		scope   ipamVlanScope
+		matches bool
+	}{
+		{ipamVlanScope{}, true},
+		{ipamVlanScope{domain: "DC-01"}, true},
+		{ipamVlanScope{domain: "DC-02"}, false},
+		{ipamVlanScope{switches: map[string]bool{"11": true}}, true},
+		{ipamVlanScope{switches: map[string]bool{"12": true}}, false},
+		{ipamVlanScope{switches: map[string]bool{}}, false},
+		{ipamVlanScope{switches: map[string]bool{"10": true}, domain: "DC-02"}, false},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing vlan scope, %v", i)
+		t.Run(testname, func(t *testing.T) {
+			if ans := tt.scope.matches(vlan); ans != tt.matches {
+				t.Errorf("got %v, want %v", ans, tt.matches)
+			}
+		})
+	}
+}
+
+func TestIpamVlanMatchFromConfig(t *testing.T) {
+	var tests = []struct {
+		raw          map[string]interface{}
+		within_range bool
+		match        ipamVlanMatch
+	}{
+		{
+			map[string]interface{}{"number": "100", "tags_exist": "DC-01", "tags_range": "DC-02"},
+			false,
+			ipamVlanMatch{number: "100", tagsAnd: "DC-01"},
+		},
+		{
+			map[string]interface{}{"number": "100", "tags_exist": "DC-01", "tags_range": "DC-02"},
+			true,
+			ipamVlanMatch{tagsAnd: "DC-02"},
+		},
+		{
+			map[string]interface{}{
+				"number": "100",
+				"match": []interface{}{map[string]interface{}{
+					"building": "B1",
+					"domain":   "DC-01",
+					"name":     "WAN",
+					"switch":   "sw1,sw2",
+					"tags_and": "A,B",
+					"tags_or":  "C",
+				}},
+			},
+			false,
+			ipamVlanMatch{building: "B1", domain: "DC-01", name: "WAN", number: "100", switches: "sw1,sw2", tagsAnd: "A,B", tagsOr: "C"},
+		},
+		{
+			map[string]interface{}{
+				"number": "100",
+				"match":  []interface{}{map[string]interface{}{"building": "B1", "number": "200"}},
+			},
+			false,
+			ipamVlanMatch{building: "B1", number: "100"},
+		},
+		{
+			map[string]interface{}{
+				"match": []interface{}{map[string]interface{}{"building": "B1", "number": "200"}},
+			},
+			false,
+			ipamVlanMatch{building: "B1", number: "200"},
+		},
+		{
+			map[string]interface{}{
+				"create_within_range": "100-199",
+				"match":               []interface{}{map[string]interface{}{"building": "B1"}},
+			},
+			true,
+			ipamVlanMatch{building: "B1"},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("Testing vlan match, %v", i), func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceIpamVlan().Schema, tt.raw)
+			m := ipamVlanMatchFromConfig(d, tt.within_range)
+			if *m != tt.match {
+				t.Errorf("got %+v, want %+v", *m, tt.match)
+			}
+		})
+	}
+}
+
+func TestFlattenIpamVlanSwitches(t *testing.T) {
+	switches := []*models.IPAMvlansSwitchesItems0{
+		{DeviceID: json.Number("10"), Name: "sw1"},
+		nil,
+		{DeviceID: json.Number("11"), Name: "sw2"},
+	}
+
+	var tests = []struct {
+		switches []*models.IPAMvlansSwitchesItems0
+		refs     string
+		names    string
+		ids      string
+	}{
+		{switches, "sw1,sw2", "sw1,sw2", "10,11"},
+		{switches, "10,11", "10,11", "10,11"},
+		{switches, "sw1, 11", "sw1,11", "10,11"},
+		{switches, "", "10,11", "10,11"},
+		{nil, "sw1", "", ""},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("Testing vlan switches, %v", i), func(t *testing.T) {
+			names, ids := flattenIpamVlanSwitches(tt.switches, tt.refs)
+			if names != tt.names || ids != tt.ids {
+				t.Errorf("got %q, %q, want %q, %q", names, ids, tt.names, tt.ids)
+			}
+		})
+	}
+}
+
+func TestIpamVlanFromRangePaged(t *testing.T) {
+	// one vlan per page, 101 is only on the second page.
+	s := &testIpamServer{
+		vlans: []interface{}{
+			map[string]interface{}{"vlan_id": 1, "number": 100},
+			map[string]interface{}{"vlan_id": 2, "number": 101},
+		},
+	}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	meta := &apiClient{
+		Device42: client.NewHTTPClientWithConfig(nil, &client.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/",
+			Schemes:  []string{"http"},
+		}),
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceIpamVlan().Schema, map[string]interface{}{
+		"name":                "WAN",
+		"create_within_range": "100-102",
+	})
+
+	vlan, diags := ipamVlanFromRange(context.Background(), d, meta)
+	if diags != nil {
+		t.Fatalf("ipamVlanFromRange: %v", diags)
+	}
+	if *vlan != 102 {
+		t.Errorf("got VLAN %d, want 102", *vlan)
+	}
+}
+
+func TestSetIpamVlanCleared(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceIpamVlan().Schema, map[string]interface{}{
+		"name":        "WAN",
+		"description": "uplink",
+		"notes":       "tf",
+	})
+
+	// fields cleared in Device42 come back as null.
+	setIpamVlan(d, &models.IPAMvlans{Name: "WAN", Number: json.Number("100")})
+
+	for _, k := range []string{"description", "notes"} {
+		if got := d.Get(k).(string); got != "" {
+			t.Errorf("%s = %q, want it cleared", k, got)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+func resourceMacAddress() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage MAC addresses.",
+
+		CreateContext: resourceMacAddressCreate,
+		ReadContext:   resourceMacAddressRead,
+		UpdateContext: resourceMacAddressUpdate,
+		DeleteContext: resourceMacAddressDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"macaddress": {
+				Description: "MAC address.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"device_id": {
+				Description:   "ID of the device the MAC address belongs to.",
+				Type:          schema.TypeString,
+				Computed:      true,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"device_name"},
+			},
+			"device_name": {
+				Description: "Name of the device the MAC address belongs to.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"port_name": {
+				Description: "Name of the port.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"vlan_id": {
+				Description: "VLAN ID.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"macaddress_id": {
+				Description: "MAC address ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("MAC address"),
+		},
+	}
+}
+
+func resourceMacAddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewPostIPAMMacsParamsWithContext(ctx)
+
+	mac := d.Get("macaddress").(string)
+	params.Macaddress = &mac
+
+	// posting a MAC address updates the existing one.
+	existing, diags := macAddressID(ctx, meta, mac)
+	if diags != nil {
+		return diags
+	}
+	if existing != "" {
+		return diag.Errorf("error MAC address %s already exists with ID %s, import it to manage it.", mac, existing)
+	}
+
+	if v, ok := d.GetOk("device_id"); ok {
+		name, diags := deviceName(ctx, meta, v.(string))
+		if diags != nil {
+			return diags
+		}
+		params.Device = &name
+	} else if v, ok := d.GetOk("device_name"); ok {
+		if s, ok := v.(string); ok {
+			params.Device = &s
+		}
+	}
+	if v, ok := d.GetOk("port_name"); ok {
+		if s, ok := v.(string); ok {
+			params.PortName = &s
+		}
+	}
+	if v, ok := d.GetOk("vlan_id"); ok {
+		if s, ok := v.(string); ok {
+			params.VlanID = &s
+		}
+	}
+
+	resp, err := client.IPam.PostIPAMMacs(params)
+
+	if err != nil {
+		return diag.Errorf("error creating MAC address. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating MAC address. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	return resourceMacAddressRead(ctx, d, meta)
+}
+
+func resourceMacAddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMMacsIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting MAC address ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.IPam.GetIPAMMacsID(params)
+
+	if _, ok := err.(*ipam.GetIPAMMacsIDNotFound); ok && !d.IsNewResource() {
+		log.Printf("[WARN] MAC address %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error reading MAC address. %s", err)
+	}
+
+	mac := resp.Payload
+
+	d.Set("macaddress", stringOrEmpty(mac.Macaddress))
+	d.Set("port_name", stringOrEmpty(mac.PortName))
+	d.Set("macaddress_id", id)
+
+	if mac.VlanID != nil {
+		d.Set("vlan_id", fmt.Sprint(mac.VlanID))
+	} else {
+		d.Set("vlan_id", "")
+	}
+
+	if mac.Device != nil {
+		d.Set("device_id", fmt.Sprint(mac.Device.DeviceID))
+		d.Set("device_name", stringOrEmpty(mac.Device.Name))
+	} else {
+		d.Set("device_id", "")
+		d.Set("device_name", "")
+	}
+
+	return nil
+}
+
+func resourceMacAddressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	// the MAC address is the key, posting it again updates the record.
+	params := ipam.NewPostIPAMMacsParamsWithContext(ctx)
+
+	mac := d.Get("macaddress").(string)
+	params.Macaddress = &mac
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "port_name", "vlan_id")...))
+
+	if v := d.Get("device_name").(string); v != "" {
+		params.Device = &v
+	}
+	if d.HasChange("port_name") {
+		v := d.Get("port_name").(string)
+		params.PortName = &v
+	}
+	if d.HasChange("vlan_id") {
+		v := d.Get("vlan_id").(string)
+		params.VlanID = &v
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.IPam.PostIPAMMacs(params)
+
+	if err != nil {
+		return diag.Errorf("error updating MAC address. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating MAC address. %s", msg[0])
+	}
+
+	d.Partial(false)
+
+	return resourceMacAddressRead(ctx, d, meta)
+}
+
+func resourceMacAddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "MAC address") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewDeleteIPAMMacsIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting MAC address ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.IPam.DeleteIPAMMacsID(params)
+
+	if err != nil {
+		return diag.Errorf("error deleting MAC address. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting MAC address.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// macAddressID returns the ID of the MAC address, empty if it doesn't exist.
+// Device42 stores MAC addresses without separators.
+func macAddressID(ctx context.Context, meta interface{}, mac string) (string, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMMacsParamsWithContext(ctx)
+	params.SetMac(&mac)
+
+	resp, err := client.IPam.GetIPAMMacs(params)
+
+	if err != nil {
+		return "", diag.Errorf("error retrieving MAC addresses. %s", err)
+	}
+
+	for _, m := range resp.Payload.Macaddresses {
+		if m != nil && normalizeMacAddress(stringOrEmpty(m.Macaddress)) == normalizeMacAddress(mac) {
+			return stringOrNumber(m.MacaddressID), nil
+		}
+	}
+
+	return "", nil
+}
+
+func normalizeMacAddress(mac string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestNormalizeMacAddress(t *testing.T) {
+	for _, mac := range []string{"02:00:00:AB:cd:01", "02-00-00-ab-cd-01", "0200.00ab.cd01", "020000abcd01"} {
+		if got := normalizeMacAddress(mac); got != "020000abcd01" {
+			t.Errorf("normalizeMacAddress(%s) = %s, want 020000abcd01", mac, got)
+		}
+	}
+}
+
+func TestAccResourceMacAddress_device(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+	mac := fmt.Sprintf("02:00:00:%02x:%02x:%02x", acctest.RandIntRange(0, 255), acctest.RandIntRange(0, 255), acctest.RandIntRange(0, 255))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceMacAddressConfig(name, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_mac_address.test", "macaddress", mac),
+					resource.TestCheckResourceAttr("device42_mac_address.test", "device_name", name),
+					resource.TestCheckResourceAttrPair("device42_mac_address.test", "device_id", "device42_device.test", "device_id"),
+				),
+			},
+			{
+				ResourceName:      "device42_mac_address.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceMacAddressConfig(name, mac string) string {
+	return fmt.Sprintf(`
+resource "device42_device" "test" {
+  name = "%s"
+  type = "virtual"
+}
+
+resource "device42_mac_address" "test" {
+  macaddress = "%s"
+  device_id  = device42_device.test.device_id
+}
+`, name, mac)
+}
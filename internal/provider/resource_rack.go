@@ -0,0 +1,470 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poroping/libdevice42/client/racks"
+	"github.com/poroping/libdevice42/client/rooms"
+	"github.com/poroping/libdevice42/models"
+)
+
+func resourceRack() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage racks.",
+
+		CreateContext: resourceRackCreate,
+		ReadContext:   resourceRackRead,
+		UpdateContext: resourceRackUpdate,
+		DeleteContext: resourceRackDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Rack name, unique within the room.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"room_id": {
+				Description: "ID of the room the rack is in.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"size": {
+				Description:  "Height in U.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"building": {
+				Description: "Name of the building the rack is in.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"room": {
+				Description: "Name of the room the rack is in.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"first_number": {
+				Description: "Number of the first U.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"custom_fields": customFieldsSchema(),
+			"manufacturer": {
+				Description: "Manufacturer name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"numbering_start_from_bottom": {
+				Description: "Number the U from the bottom of the rack.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"orientation": {
+				Description:  "Orientation of the rack in the room layout. One of `up`, `down`, `left` or `right`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"up", "down", "left", "right"}, false),
+			},
+			"row": {
+				Description: "Name of the row the rack is in.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"row_size": {
+				Description: "How many rows of the room grid the rack takes up.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"col_size": {
+				Description: "How many columns of the room grid the rack takes up.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"start_row": {
+				Description: "Row of the room grid the rack starts at.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"start_col": {
+				Description: "Column of the room grid the rack starts at.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"rack_id": {
+				Description: "Rack ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("rack"),
+		},
+	}
+}
+
+func resourceRackCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := racks.NewPostRacksParamsWithContext(ctx)
+
+	name := d.Get("name").(string)
+	params.Name = &name
+	room_id := d.Get("room_id").(string)
+	params.RoomID = &room_id
+	size := int64(d.Get("size").(int))
+	params.Size = &size
+	params.NumberingStartFromBottom = yesNo(d.Get("numbering_start_from_bottom").(bool))
+
+	if v, ok := d.GetOk("manufacturer"); ok {
+		if s, ok := v.(string); ok {
+			params.Manufacturer = &s
+		}
+	}
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+	if v, ok := d.GetOk("orientation"); ok {
+		if s, ok := v.(string); ok {
+			params.Orientation = &s
+		}
+	}
+	if v, ok := d.GetOk("row"); ok {
+		if s, ok := v.(string); ok {
+			params.Row = &s
+		}
+	}
+
+	expandRackGrid(d, params, false)
+
+	resp, err := client.Racks.PostRacks(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error creating rack. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating rack. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "rack", d.Id())); diags != nil {
+		return diags
+	}
+
+	return resourceRackRead(ctx, d, meta)
+}
+
+func resourceRackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := racks.NewGetRacksIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting rack ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Racks.GetRacksID(params, nil)
+
+	if _, ok := err.(*racks.GetRacksIDNotFound); ok && !d.IsNewResource() {
+		log.Printf("[WARN] rack %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error reading rack. %s", err)
+	}
+
+	rack := resp.Payload
+
+	d.Set("name", stringOrEmpty(rack.Name))
+	d.Set("size", intOrZero(rack.Size))
+	d.Set("building", stringOrEmpty(rack.Building))
+	d.Set("room", stringOrEmpty(rack.Room))
+	d.Set("first_number", intOrZero(rack.FirstNumber))
+	d.Set("manufacturer", stringOrEmpty(rack.Manufacturer))
+	d.Set("notes", stringOrEmpty(rack.Notes))
+	d.Set("row", stringOrEmpty(rack.Row))
+	d.Set("row_size", intOrZero(rack.RowSize))
+	d.Set("start_row", intOrZero(rack.StartRow))
+	d.Set("start_col", intOrZero(rack.StartCol))
+	d.Set("rack_id", id)
+
+	if v, ok := d.GetOk("custom_fields"); ok {
+		d.Set("custom_fields", flattenCustomFields(rackCustomFields(rack.CustomFields), v.(map[string]interface{})))
+	}
+	if b, ok := parseYesNo(rack.NumberingStartFromBottom); ok {
+		d.Set("numbering_start_from_bottom", b)
+	}
+
+	// the rack itself doesn't return its room ID, orientation or width, the
+	// room it is in does.
+	room_id, r, diags := rackRoom(ctx, meta, d.Get("room_id").(string), id, stringOrEmpty(rack.Room), stringOrEmpty(rack.Building))
+	if diags != nil {
+		return diags
+	}
+	d.Set("room_id", room_id)
+	if r != nil {
+		d.Set("orientation", stringOrEmpty(r.Orientation))
+		d.Set("col_size", intOrZero(r.ColSize))
+	}
+
+	return nil
+}
+
+func resourceRackUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := racks.NewPostRacksParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return diag.Errorf("error getting rack ID. %s", err)
+	}
+	params.SetRackID(&i)
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "manufacturer", "notes", "row")...))
+
+	if d.HasChange("name") {
+		v := d.Get("name").(string)
+		params.NewName = &v
+	}
+	if d.HasChange("room_id") {
+		v := d.Get("room_id").(string)
+		params.RoomID = &v
+	}
+	if d.HasChange("size") {
+		v := int64(d.Get("size").(int))
+		params.Size = &v
+	}
+	if d.HasChange("manufacturer") {
+		v := d.Get("manufacturer").(string)
+		params.Manufacturer = &v
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+	if d.HasChange("numbering_start_from_bottom") {
+		params.NumberingStartFromBottom = yesNo(d.Get("numbering_start_from_bottom").(bool))
+	}
+	if d.HasChange("orientation") {
+		v := d.Get("orientation").(string)
+		params.Orientation = &v
+	}
+	if d.HasChange("row") {
+		v := d.Get("row").(string)
+		params.Row = &v
+	}
+
+	expandRackGrid(d, params, true)
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.Racks.PostRacks(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error updating rack. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating rack. %s", msg[0])
+	}
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "rack", d.Id())); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceRackRead(ctx, d, meta)
+}
+
+func resourceRackDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "rack") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := racks.NewDeleteRacksIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting rack ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Racks.DeleteRacksID(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error deleting rack. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting rack.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// expandRackGrid sets the configured room grid position, only the changed
+// values on update.
+func expandRackGrid(d *schema.ResourceData, params *racks.PostRacksParams, update bool) {
+	for k, p := range map[string]**string{
+		"first_number": &params.FirstNumber,
+		"row_size":     &params.RowSize,
+		"col_size":     &params.ColSize,
+		"start_row":    &params.StartRow,
+		"start_col":    &params.StartCol,
+	} {
+		if update && !d.HasChange(k) {
+			continue
+		}
+		if v, ok := d.GetOk(k); ok {
+			s := strconv.Itoa(v.(int))
+			*p = &s
+		}
+	}
+}
+
+// rackRoom returns the room the rack is in and the rack as listed there. The
+// known room_id is kept while the rack is still in it, otherwise the room is
+// looked up by name within the building, empty if that isn't unique.
+func rackRoom(ctx context.Context, meta interface{}, room_id, rack_id, room, building string) (string, *models.RoomsRacksRack, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	if room_id != "" {
+		r, diags := getRoomRack(ctx, meta, room_id, rack_id)
+		if diags != nil || r != nil {
+			return room_id, r, diags
+		}
+	}
+
+	if room == "" {
+		return "", nil, nil
+	}
+
+	params := rooms.NewGetRoomsParamsWithContext(ctx)
+	params.SetName(&room)
+	if building != "" {
+		params.SetBuilding(&building)
+	}
+
+	resp, err := client.Rooms.GetRooms(params, nil)
+
+	if err != nil {
+		return "", nil, diag.Errorf("error retrieving rooms. %s", err)
+	}
+
+	if len(resp.Payload.Rooms) != 1 || resp.Payload.Rooms[0] == nil {
+		log.Printf("[WARN] room %s in building %s not found or not unique", room, building)
+		return "", nil, nil
+	}
+
+	room_id = stringOrNumber(resp.Payload.Rooms[0].RoomID)
+
+	r, diags := getRoomRack(ctx, meta, room_id, rack_id)
+
+	return room_id, r, diags
+}
+
+// rackCustomFields converts the rack custom fields for flattenCustomFields.
+func rackCustomFields(fields []*racks.CustomFieldsItems0) []*customField {
+	l := make([]*customField, 0, len(fields))
+	for _, f := range fields {
+		if f == nil {
+			continue
+		}
+		l = append(l, &customField{Key: f.Key, Notes: f.Notes, Value: f.Value})
+	}
+	return l
+}
+
+func rackCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	i, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, diag.Errorf("error getting rack ID. %s", err)
+	}
+
+	params := racks.NewGetRacksIDParamsWithContext(ctx)
+	params.SetID(i)
+
+	resp, err := client.Racks.GetRacksID(params, nil)
+
+	if err != nil {
+		return nil, diag.Errorf("error reading rack %s. %s", id, err)
+	}
+
+	return rackCustomFields(resp.Payload.CustomFields), nil
+}
+
+// getRoomRack returns the rack as listed in the room, nil if it isn't or the
+// room is gone.
+func getRoomRack(ctx context.Context, meta interface{}, room_id, rack_id string) (*models.RoomsRacksRack, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewGetRoomsIDParamsWithContext(ctx)
+	i, err := strconv.ParseInt(room_id, 10, 64)
+	if err != nil {
+		return nil, diag.Errorf("error getting room ID. %s", err)
+	}
+	params.SetID(i)
+
+	resp, err := client.Rooms.GetRoomsID(params, nil)
+
+	if _, ok := err.(*rooms.GetRoomsIDNotFound); ok {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, diag.Errorf("error reading room. %s", err)
+	}
+
+	for _, r := range resp.Payload.Racks {
+		if r != nil && r.Rack != nil && stringOrNumber(r.Rack.RackID) == rack_id {
+			return r.Rack, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/poroping/libdevice42/client/devices"
+	"github.com/poroping/libdevice42/client/racks"
+)
+
+func resourceRackMount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage the position of a device in a rack.",
+
+		CreateContext: resourceRackMountCreate,
+		ReadContext:   resourceRackMountRead,
+		UpdateContext: resourceRackMountUpdate,
+		DeleteContext: resourceRackMountDelete,
+
+		CustomizeDiff: resourceRackMountCustomizeDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"device_id": {
+				Description: "ID of the device to mount.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"rack_id": {
+				Description: "ID of the rack to mount the device in.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"start_at": {
+				Description:  "Lowest U the device takes up.",
+				Type:         schema.TypeFloat,
+				Required:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"orientation": {
+				Description:  "Whether the device faces the `front` or the `back` of the rack.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "front",
+				ValidateFunc: validation.StringInSlice([]string{"front", "back"}, false),
+			},
+			"side": {
+				Description:  "Side of the rack a 0U device is mounted on, `left` or `right`. Leave empty to mount the device in the U space.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"left", "right"}, false),
+			},
+			"size": {
+				Description: "Height of the device in U, from its hardware model.",
+				Type:        schema.TypeFloat,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("rack mount"),
+		},
+	}
+}
+
+func resourceRackMountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := rackMountPost(ctx, d, meta); diags != nil {
+		return diags
+	}
+
+	d.SetId(d.Get("device_id").(string))
+
+	return resourceRackMountRead(ctx, d, meta)
+}
+
+func resourceRackMountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewGetDevicesIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting device ID. %s", err)
+	}
+	params.SetDeviceID(int64(i))
+
+	resp, err := client.Devices.GetDevicesID(params, nil)
+
+	if _, ok := err.(*devices.GetDevicesIDNotFound); ok && !d.IsNewResource() {
+		log.Printf("[WARN] device %s not found, removing rack mount from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error reading device. %s", err)
+	}
+
+	device := resp.Payload
+
+	rack_id := stringOrNumber(device.RackID)
+	if rack_id == "" && !d.IsNewResource() {
+		log.Printf("[WARN] device %s is no longer in a rack, removing rack mount from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("device_id", id)
+	d.Set("rack_id", rack_id)
+	d.Set("start_at", floatOrZero(device.StartAt))
+	d.Set("orientation", rackMountOrientation(device.Orientation))
+	d.Set("size", floatOrZero(device.HwSize))
+
+	switch where := strings.ToLower(stringOrEmpty(device.Where)); where {
+	case "left", "right":
+		d.Set("side", where)
+	default:
+		d.Set("side", "")
+	}
+
+	return nil
+}
+
+func resourceRackMountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges("rack_id", "start_at", "orientation", "side") {
+		return resourceRackMountRead(ctx, d, meta)
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	if diags := rackMountPost(ctx, d, meta); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceRackMountRead(ctx, d, meta)
+}
+
+func resourceRackMountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "rack mount") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := devices.NewDeleteDeviceRackDeviceIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting device ID. %s", err)
+	}
+	params.SetDeviceID(int64(i))
+
+	resp, err := client.Devices.DeleteDeviceRackDeviceID(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error removing device %s from its rack. %s", id, err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error removing device %s from its rack.", id)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// rackMountPost mounts the device at the configured position, moving it if it
+// is already racked.
+func rackMountPost(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := formParams{
+		"device_id":   d.Get("device_id").(string),
+		"rack_id":     d.Get("rack_id").(string),
+		"start_at":    strconv.FormatFloat(d.Get("start_at").(float64), 'f', -1, 64),
+		"orientation": d.Get("orientation").(string),
+		"where":       "mounted",
+	}
+	if v := d.Get("side").(string); v != "" {
+		params["where"] = v
+	}
+
+	if err := postDeviceRack(ctx, client, params); err != nil {
+		return diag.Errorf("error mounting device %s in rack %s. %s", params["device_id"], params["rack_id"], err)
+	}
+
+	return nil
+}
+
+// resourceRackMountCustomizeDiff checks at plan time that the U space the
+// device would take up is inside the rack and not used by another device.
+// It is skipped for 0U devices and while the device or rack are unknown.
+func resourceRackMountCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("device_id") && !d.HasChange("rack_id") && !d.HasChange("start_at") && !d.HasChange("side") {
+		return nil
+	}
+	if d.Get("side").(string) != "" {
+		return nil
+	}
+	for _, k := range []string{"device_id", "rack_id", "start_at"} {
+		if !d.NewValueKnown(k) {
+			return nil
+		}
+	}
+
+	device_id := d.Get("device_id").(string)
+	rack_id := d.Get("rack_id").(string)
+
+	device, diags := getDevice(ctx, meta, device_id)
+	if diags != nil {
+		return fmt.Errorf("%s", diags[0].Summary)
+	}
+
+	size := floatOrZero(device.HwSize)
+	if size == 0 {
+		log.Printf("[DEBUG] device %s has no size, skipping rack space check", device_id)
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := racks.NewGetRacksIDParamsWithContext(ctx)
+	i, err := strconv.ParseInt(rack_id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("error getting rack ID. %s", err)
+	}
+	params.SetID(i)
+
+	resp, err := client.Racks.GetRacksID(params, nil)
+
+	if err != nil {
+		return fmt.Errorf("error reading rack %s. %s", rack_id, err)
+	}
+
+	used := make([]rackSlot, 0, len(resp.Payload.Devices))
+	for _, v := range resp.Payload.Devices {
+		if v == nil || v.Device == nil || stringOrNumber(v.Device.DeviceID) == device_id {
+			continue
+		}
+		if where := strings.ToLower(stringOrEmpty(v.Where)); where != "" && where != "mounted" {
+			continue
+		}
+		used = append(used, rackSlot{
+			name:    stringOrEmpty(v.Device.Name),
+			startAt: floatOrZero(v.StartAt),
+			size:    floatOrZero(v.Size),
+		})
+	}
+
+	slot := rackSlot{startAt: d.Get("start_at").(float64), size: size}
+
+	return slot.fits(floatOrZero(resp.Payload.Size), used)
+}
+
+// rackSlot is the U space a device takes up, from startAt up.
+type rackSlot struct {
+	name    string
+	startAt float64
+	size    float64
+}
+
+// fits returns an error if the slot is outside a rack of the given size or
+// overlaps one of the used slots.
+func (s rackSlot) fits(rackSize float64, used []rackSlot) error {
+	if s.startAt < 1 || s.startAt+s.size-1 > rackSize {
+		return fmt.Errorf("error %gU device at U%g doesn't fit in a %gU rack.", s.size, s.startAt, rackSize)
+	}
+	for _, u := range used {
+		if s.startAt < u.startAt+u.size && u.startAt < s.startAt+s.size {
+			return fmt.Errorf("error U%g-U%g is used by %s at U%g-U%g.", s.startAt, s.startAt+s.size-1, u.name, u.startAt, u.startAt+u.size-1)
+		}
+	}
+	return nil
+}
+
+// rackMountOrientation returns the orientation of a racked device as `front`
+// or `back`, older Device42 versions return it as 1 or 2.
+func rackMountOrientation(v interface{}) string {
+	switch strings.ToLower(stringOrNumber(v)) {
+	case "back", "2":
+		return "back"
+	default:
+		return "front"
+	}
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestRackSlotFits(t *testing.T) {
+	used := []rackSlot{
+		{name: "sw01", startAt: 40, size: 1},
+		{name: "srv01", startAt: 10, size: 2},
+	}
+
+	var tests = []struct {
+		startAt, size float64
+		fits          bool
+	}{
+		{1, 1, true},
+		{41, 2, true},
+		{42, 1, true},
+		{42, 2, false},
+		{0, 1, false},
+		{40, 1, false},
+		{9, 2, false},
+		{11, 1, false},
+		{12, 4, true},
+		{8, 2, true},
+		{10.5, 1, false},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing rack slot, %v", i)
+		t.Run(testname, func(t *testing.T) {
+			err := rackSlot{startAt: tt.startAt, size: tt.size}.fits(42, used)
+			if (err == nil) != tt.fits {
+				t.Errorf("got %v, want fits %v", err, tt.fits)
+			}
+		})
+	}
+}
+
+func TestRackMountOrientation(t *testing.T) {
+	for v, want := range map[interface{}]string{
+		"Front": "front",
+		"back":  "back",
+		"2":     "back",
+		nil:     "front",
+	} {
+		if got := rackMountOrientation(v); got != want {
+			t.Errorf("rackMountOrientation(%v) = %s, want %s", v, got, want)
+		}
+	}
+}
+
+func TestAccResourceRackMount_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRackMountConfig(name, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("device42_rack_mount.test", "rack_id", "device42_rack.test", "rack_id"),
+					resource.TestCheckResourceAttr("device42_rack_mount.test", "start_at", "10"),
+					resource.TestCheckResourceAttr("device42_rack_mount.test", "orientation", "front"),
+				),
+			},
+			{
+				Config: testAccResourceRackMountConfig(name, 20),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_rack_mount.test", "start_at", "20"),
+				),
+			},
+			{
+				ResourceName:      "device42_rack_mount.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceRackMountConfig(name string, start_at int) string {
+	return fmt.Sprintf(`
+resource "device42_building" "test" {
+  name = "%[1]s"
+}
+
+resource "device42_room" "test" {
+  name        = "%[1]s"
+  building_id = device42_building.test.building_id
+}
+
+resource "device42_rack" "test" {
+  name    = "%[1]s"
+  room_id = device42_room.test.room_id
+  size    = 42
+}
+
+resource "device42_device" "test" {
+  name = "%[1]s"
+}
+
+resource "device42_rack_mount" "test" {
+  device_id = device42_device.test.device_id
+  rack_id   = device42_rack.test.rack_id
+  start_at  = %[2]d
+}
+`, name, start_at)
+}
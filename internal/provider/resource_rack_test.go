@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceRack_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceRackConfig(name, 42),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_room.test", "building", name),
+					resource.TestCheckResourceAttrPair("device42_room.test", "building_id", "device42_building.test", "building_id"),
+					resource.TestCheckResourceAttr("device42_rack.test", "size", "42"),
+					resource.TestCheckResourceAttr("device42_rack.test", "room", name),
+					resource.TestCheckResourceAttrPair("device42_rack.test", "room_id", "device42_room.test", "room_id"),
+					resource.TestCheckResourceAttr("data.device42_rack.test", "size", "42"),
+				),
+			},
+			{
+				Config: testAccResourceRackConfig(name, 48),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_rack.test", "size", "48"),
+				),
+			},
+			{
+				ResourceName:      "device42_room.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "device42_rack.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceRackConfig(name string, size int) string {
+	return fmt.Sprintf(`
+resource "device42_building" "test" {
+  name = "%[1]s"
+}
+
+resource "device42_room" "test" {
+  name        = "%[1]s"
+  building_id = device42_building.test.building_id
+}
+
+resource "device42_rack" "test" {
+  name        = "%[1]s"
+  room_id     = device42_room.test.room_id
+  size        = %[2]d
+  orientation = "up"
+}
+
+data "device42_rack" "test" {
+  name    = device42_rack.test.name
+  room_id = device42_room.test.room_id
+
+  depends_on = [device42_rack.test]
+}
+`, name, size)
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/poroping/libdevice42/client/rooms"
+)
+
+func resourceRoom() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage rooms.",
+
+		CreateContext: resourceRoomCreate,
+		ReadContext:   resourceRoomRead,
+		UpdateContext: resourceRoomUpdate,
+		DeleteContext: resourceRoomDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Room name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"building_id": {
+				Description: "ID of the building the room is in.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"building": {
+				Description: "Name of the building the room is in.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"notes": {
+				Description: "Notes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"custom_fields": customFieldsSchema(),
+			"room_id": {
+				Description: "Room ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("room"),
+		},
+	}
+}
+
+func resourceRoomCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewPostRoomsParamsWithContext(ctx)
+	params.Name = d.Get("name").(string)
+
+	building_id := d.Get("building_id").(string)
+	params.BuildingID = &building_id
+
+	if v, ok := d.GetOk("notes"); ok {
+		if s, ok := v.(string); ok {
+			params.Notes = &s
+		}
+	}
+
+	resp, err := client.Rooms.PostRooms(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error creating room. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error creating room. %s", msg[0])
+	}
+
+	d.SetId(string(msg[1]))
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "room", d.Id())); diags != nil {
+		return diags
+	}
+
+	return resourceRoomRead(ctx, d, meta)
+}
+
+func resourceRoomRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewGetRoomsIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting room ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Rooms.GetRoomsID(params, nil)
+
+	if _, ok := err.(*rooms.GetRoomsIDNotFound); ok && !d.IsNewResource() {
+		log.Printf("[WARN] room %s not found, removing from state", id)
+		d.SetId("")
+		return nil
+	}
+
+	if err != nil {
+		return diag.Errorf("error reading room. %s", err)
+	}
+
+	room := resp.Payload
+
+	d.Set("name", stringOrEmpty(room.Name))
+	d.Set("building_id", stringOrNumber(room.BuildingID))
+	d.Set("building", stringOrEmpty(room.Building))
+	d.Set("notes", stringOrEmpty(room.Notes))
+	d.Set("room_id", id)
+
+	// a single room is returned without custom fields, only look them up
+	// when they're used.
+	if v, ok := d.GetOk("custom_fields"); ok {
+		fields, diags := roomCustomFieldsByID(ctx, meta, id)
+		if diags != nil {
+			return diags
+		}
+		d.Set("custom_fields", flattenCustomFields(fields, v.(map[string]interface{})))
+	}
+
+	return nil
+}
+
+func resourceRoomUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewPutRoomsIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting room ID. %s", err)
+	}
+	params.SetID(int64(i))
+	params.SetContext(withClearedFormParams(ctx, changedToEmpty(d, "notes")...))
+
+	if d.HasChange("name") {
+		v := d.Get("name").(string)
+		params.Name = &v
+	}
+	if d.HasChange("building_id") {
+		v := d.Get("building_id").(string)
+		params.BuildingID = &v
+	}
+	if d.HasChange("notes") {
+		v := d.Get("notes").(string)
+		params.Notes = &v
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	resp, err := client.Rooms.PutRoomsID(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error updating room. %s", err)
+	}
+
+	j_code := resp.Payload.Code.(json.Number)
+	code, _ := j_code.Int64()
+	msg := intList(resp.Payload.Msg.([]interface{}))
+	if code != 0 {
+		return diag.Errorf("error updating room. %s", msg[0])
+	}
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "room", d.Id())); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceRoomRead(ctx, d, meta)
+}
+
+func resourceRoomDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "room") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewDeleteRoomsIDParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting room ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.Rooms.DeleteRoomsID(params, nil)
+
+	if err != nil {
+		return diag.Errorf("error deleting room. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting room.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// roomCustomFieldsByID returns the custom fields of a room, which only the
+// room list has.
+func roomCustomFieldsByID(ctx context.Context, meta interface{}, id string) ([]*customField, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := rooms.NewGetRoomsParamsWithContext(ctx)
+
+	resp, err := client.Rooms.GetRooms(params, nil)
+
+	if err != nil {
+		return nil, diag.Errorf("error retrieving rooms. %s", err)
+	}
+
+	for _, r := range resp.Payload.Rooms {
+		if r != nil && stringOrNumber(r.RoomID) == id {
+			return customFieldList(r.CustomFields), nil
+		}
+	}
+
+	return nil, diag.Errorf("error room %s not found.", id)
+}
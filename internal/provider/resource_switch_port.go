@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+func resourceSwitchPort() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manage switch ports and the VLANs on them.",
+
+		CreateContext: resourceSwitchPortCreate,
+		ReadContext:   resourceSwitchPortRead,
+		UpdateContext: resourceSwitchPortUpdate,
+		DeleteContext: resourceSwitchPortDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			"switch_id": {
+				Description: "Device ID of the switch the port is on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"port": {
+				Description: "Port name, e.g. `Gi1/0/1`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "Port description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"type": {
+				Description: "Port type. Has to exist in Device42.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+			},
+			"vlan_ids": {
+				Description:      "Comma separated IDs of the VLANs on the port, the `vlan_id` of `device42_ipam_vlan`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffFakeListEqual,
+			},
+			"macaddress": {
+				Description: "MAC address of the port.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"remote_port_id": {
+				Description: "ID of the switch port this port is connected to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"custom_fields": customFieldsSchema(),
+			"switch_port_id": {
+				Description: "Switch port ID.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"deletion_policy": deletionPolicySchema("switch port"),
+		},
+	}
+}
+
+func resourceSwitchPortCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	switch_name, diags := deviceName(ctx, meta, d.Get("switch_id").(string))
+	if diags != nil {
+		return diags
+	}
+
+	params := formParams{
+		"switch": switch_name,
+		"port":   d.Get("port").(string),
+	}
+
+	for k, p := range map[string]string{
+		"description":    "description",
+		"type":           "type",
+		"vlan_ids":       "vlan_ids",
+		"macaddress":     "hwaddress",
+		"remote_port_id": "remote_port_id",
+	} {
+		if v, ok := d.GetOk(k); ok {
+			params[p] = v.(string)
+		}
+	}
+
+	id, err := postSwitchport(ctx, client, params)
+
+	if err != nil {
+		return diag.Errorf("error creating switch port. %s", err)
+	}
+
+	d.SetId(id)
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "switchport", d.Id())); diags != nil {
+		return diags
+	}
+
+	return resourceSwitchPortRead(ctx, d, meta)
+}
+
+func resourceSwitchPortRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	id := d.Id()
+
+	port, diags := getSwitchPort(ctx, meta, d.Get("switch_id").(string), id)
+	if diags != nil {
+		return diags
+	}
+
+	if port == nil {
+		if !d.IsNewResource() {
+			log.Printf("[WARN] switch port %s not found, removing from state", id)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error switch port %s not found.", id)
+	}
+
+	d.Set("port", stringOrNumber(port.Port))
+	d.Set("description", stringOrEmpty(port.Description))
+	d.Set("type", stringOrNumber(port.Type))
+	d.Set("vlan_ids", flattenSwitchPortVlanIDs(port.VlanIds))
+	d.Set("macaddress", switchPortMacAddress(port.Macs, d.Get("macaddress").(string)))
+	d.Set("switch_port_id", id)
+
+	// without custom fields in the response the configured ones are kept.
+	if v, ok := d.GetOk("custom_fields"); ok && port.CustomFields != nil {
+		d.Set("custom_fields", flattenCustomFields(port.CustomFields, v.(map[string]interface{})))
+	}
+
+	if port.Switch != nil {
+		d.Set("switch_id", stringOrNumber(port.Switch.DeviceID))
+	}
+
+	return nil
+}
+
+func resourceSwitchPortUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient).Device42
+
+	switch_name, diags := deviceName(ctx, meta, d.Get("switch_id").(string))
+	if diags != nil {
+		return diags
+	}
+
+	// the switch and port name are the key, posting them again updates the
+	// port.
+	params := formParams{
+		"switch": switch_name,
+		"port":   d.Get("port").(string),
+	}
+
+	if d.HasChange("port") {
+		o, n := d.GetChange("port")
+		params["port"] = o.(string)
+		params["new_port"] = n.(string)
+	}
+
+	for k, p := range map[string]string{
+		"description": "description",
+		"type":        "type",
+		"vlan_ids":    "vlan_ids",
+		"macaddress":  "hwaddress",
+	} {
+		if d.HasChange(k) {
+			params[p] = d.Get(k).(string)
+		}
+	}
+
+	if d.HasChange("remote_port_id") {
+		if v := d.Get("remote_port_id").(string); v != "" {
+			params["remote_port_id"] = v
+		} else {
+			params["remote_port_clear"] = "yes"
+		}
+	}
+
+	// keep the prior state if the update fails part way.
+	d.Partial(true)
+
+	if _, err := postSwitchport(ctx, client, params); err != nil {
+		return diag.Errorf("error updating switch port. %s", err)
+	}
+
+	if diags := updateCustomFields(d, objectPutCustomField(ctx, meta, "switchport", d.Id())); diags != nil {
+		return diags
+	}
+
+	d.Partial(false)
+
+	return resourceSwitchPortRead(ctx, d, meta)
+}
+
+func resourceSwitchPortDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if abandonOnDestroy(d, meta, "switch port") {
+		d.SetId("")
+		return nil
+	}
+
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewDeleteIPAMSwitchportsParamsWithContext(ctx)
+	id := d.Id()
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return diag.Errorf("error getting switch port ID. %s", err)
+	}
+	params.SetID(int64(i))
+
+	resp, err := client.IPam.DeleteIPAMSwitchports(params)
+
+	if err != nil {
+		return diag.Errorf("error deleting switch port. %s", err)
+	}
+
+	if b, ok := parseYesNo(resp.Payload.Deleted); !ok || !b {
+		return diag.Errorf("error deleting switch port.")
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// getSwitchPort returns the switch port with the ID, nil if it doesn't exist.
+// The ports of a switch can only be listed, switch_id narrows the list down
+// when it is known, i.e. not on import.
+func getSwitchPort(ctx context.Context, meta interface{}, switch_id, id string) (*ipamSwitchport, diag.Diagnostics) {
+	client := meta.(*apiClient).Device42
+
+	params := ipam.NewGetIPAMSwitchportsParamsWithContext(ctx)
+	if switch_id != "" {
+		params.SwitchID = &switch_id
+	}
+
+	ports, err := getIPAMSwitchports(ctx, client, params)
+
+	if err != nil {
+		return nil, diag.Errorf("error retrieving switch ports. %s", err)
+	}
+
+	for _, p := range ports {
+		if p != nil && stringOrNumber(p.SwitchportID) == id {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// flattenSwitchPortVlanIDs returns the VLAN IDs of a port sorted, Device42
+// doesn't keep the order they were posted in.
+func flattenSwitchPortVlanIDs(v interface{}) string {
+	l := deleteEmpty(strings.Split(flattenTagList(v), ","))
+	sort.Slice(l, func(i, j int) bool {
+		a, err_a := strconv.Atoi(l[i])
+		b, err_b := strconv.Atoi(l[j])
+		if err_a != nil || err_b != nil {
+			return l[i] < l[j]
+		}
+		return a < b
+	})
+	return strings.Join(l, ",")
+}
+
+// switchPortMacAddress returns the MAC address of a port from its `macs`,
+// either addresses or objects with a `mac`. A port can have several, the
+// configured one is kept if it is among them.
+func switchPortMacAddress(v interface{}, configured string) string {
+	l, _ := v.([]interface{})
+
+	macs := make([]string, 0, len(l))
+	for _, m := range l {
+		switch t := m.(type) {
+		case string:
+			macs = append(macs, t)
+		case map[string]interface{}:
+			if s := stringOrEmpty(t["mac"]); s != "" {
+				macs = append(macs, s)
+			} else if s := stringOrEmpty(t["macaddress"]); s != "" {
+				macs = append(macs, s)
+			}
+		}
+	}
+
+	for _, m := range macs {
+		if configured != "" && normalizeMacAddress(m) == normalizeMacAddress(configured) {
+			return configured
+		}
+	}
+	if len(macs) > 0 {
+		return macs[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenSwitchPortVlanIDs(t *testing.T) {
+	for _, tt := range []struct {
+		v    interface{}
+		want string
+	}{
+		{[]interface{}{json.Number("12"), json.Number("3"), json.Number("100")}, "3,12,100"},
+		{"12,3", "3,12"},
+		{[]interface{}{}, ""},
+		{nil, ""},
+	} {
+		if got := flattenSwitchPortVlanIDs(tt.v); got != tt.want {
+			t.Errorf("flattenSwitchPortVlanIDs(%v) = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestSwitchPortMacAddress(t *testing.T) {
+	for _, tt := range []struct {
+		v          interface{}
+		configured string
+		want       string
+	}{
+		{[]interface{}{"02:00:00:00:00:01"}, "", "02:00:00:00:00:01"},
+		{[]interface{}{map[string]interface{}{"mac": "02:00:00:00:00:01", "vlan": nil}}, "", "02:00:00:00:00:01"},
+		{[]interface{}{"02:00:00:00:00:01", "020000000002"}, "02:00:00:00:00:02", "02:00:00:00:00:02"},
+		{[]interface{}{"02:00:00:00:00:01"}, "02:00:00:00:00:02", "02:00:00:00:00:01"},
+		{nil, "02:00:00:00:00:01", ""},
+	} {
+		if got := switchPortMacAddress(tt.v, tt.configured); got != tt.want {
+			t.Errorf("switchPortMacAddress(%v, %s) = %s, want %s", tt.v, tt.configured, got, tt.want)
+		}
+	}
+}
+
+func TestAccResourceSwitchPort_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandString(8))
+	mac := fmt.Sprintf("02:00:00:%02x:%02x:%02x", acctest.RandIntRange(0, 255), acctest.RandIntRange(0, 255), acctest.RandIntRange(0, 255))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceSwitchPortConfig(name, mac, "Gi1/0/1", "device42_ipam_vlan.access.vlan_id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_switch_port.test", "port", "Gi1/0/1"),
+					resource.TestCheckResourceAttr("device42_switch_port.test", "macaddress", mac),
+					resource.TestCheckResourceAttrPair("device42_switch_port.test", "switch_id", "device42_device.test", "device_id"),
+					resource.TestCheckResourceAttrPair("device42_switch_port.test", "vlan_ids", "device42_ipam_vlan.access", "vlan_id"),
+				),
+			},
+			{
+				Config: testAccResourceSwitchPortConfig(name, mac, "Gi1/0/2", `"${device42_ipam_vlan.access.vlan_id},${device42_ipam_vlan.voice.vlan_id}"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("device42_switch_port.test", "port", "Gi1/0/2"),
+				),
+			},
+			{
+				ResourceName:      "device42_switch_port.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccResourceSwitchPortConfig(name, mac, port, vlan_ids string) string {
+	return fmt.Sprintf(`
+resource "device42_device" "test" {
+  name = "%s"
+}
+
+resource "device42_ipam_vlan" "access" {
+  name   = "TF-ACC-TEST-ACCESS"
+  number = "3901"
+}
+
+resource "device42_ipam_vlan" "voice" {
+  name   = "TF-ACC-TEST-VOICE"
+  number = "3902"
+}
+
+resource "device42_switch_port" "test" {
+  switch_id   = device42_device.test.device_id
+  port        = "%s"
+  description = "uplink"
+  vlan_ids    = %s
+  macaddress  = "%s"
+}
+`, name, port, vlan_ids, mac)
+}
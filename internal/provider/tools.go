@@ -2,6 +2,9 @@ package provider
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 )
 
 func intList(l []interface{}) []string {
@@ -11,3 +14,106 @@ func intList(l []interface{}) []string {
 	}
 	return s
 }
+
+// yesNo converts a bool into the 'yes'/'no' strings the API expects.
+func yesNo(b bool) *string {
+	s := "no"
+	if b {
+		s = "yes"
+	}
+	return &s
+}
+
+// parseYesNo reads a flag returned by the API either as a bool or a 'yes'/'no' string.
+func parseYesNo(v interface{}) (bool, bool) {
+	switch t := v.(type) {
+	case bool:
+		return t, true
+	case string:
+		switch strings.ToLower(t) {
+		case "yes", "true":
+			return true, true
+		case "no", "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// subnetsOverlap reports whether the two networks given in address/mask bits
+// form share any addresses.
+func subnetsOverlap(network1, mask_bits1, network2, mask_bits2 string) (bool, error) {
+	_, n1, err := net.ParseCIDR(fmt.Sprintf("%s/%s", network1, mask_bits1))
+	if err != nil {
+		return false, err
+	}
+	_, n2, err := net.ParseCIDR(fmt.Sprintf("%s/%s", network2, mask_bits2))
+	if err != nil {
+		return false, err
+	}
+
+	return n1.Contains(n2.IP) || n2.Contains(n1.IP), nil
+}
+
+// stringOrEmpty returns a string field of an API response, which is null when
+// it isn't set.
+func stringOrEmpty(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return ""
+}
+
+// flattenTagList turns tags returned as a list into a comma separated string.
+func flattenTagList(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []string:
+		return strings.Join(t, ",")
+	case []interface{}:
+		return strings.Join(deleteEmpty(intList(t)), ",")
+	}
+	return ""
+}
+
+// listSubtract returns the items of the comma separated list a that are not
+// in b.
+func listSubtract(a, b string) string {
+	in_b := make(map[string]bool)
+	for _, v := range strings.Split(b, ",") {
+		in_b[strings.TrimSpace(v)] = true
+	}
+
+	l := make([]string, 0)
+	for _, v := range deleteEmpty(strings.Split(a, ",")) {
+		if v = strings.TrimSpace(v); !in_b[v] {
+			l = append(l, v)
+		}
+	}
+
+	return strings.Join(l, ",")
+}
+
+// stringOrNumber returns a string or number field of an API response as a
+// string, empty when it is null.
+func stringOrNumber(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// intOrZero returns a number field of an API response as an int, zero when it
+// is null or not a number.
+func intOrZero(v interface{}) int {
+	i, _ := strconv.Atoi(stringOrNumber(v))
+	return i
+}
+
+// floatOrZero returns a number field of an API response as a float, zero when
+// it is null or not a number.
+func floatOrZero(v interface{}) float64 {
+	f, _ := strconv.ParseFloat(stringOrNumber(v), 64)
+	return f
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSubnetsOverlap(t *testing.T) {
+	var tests = []struct {
+		network1, mask_bits1 string
+		network2, mask_bits2 string
+		overlap              bool
+	}{
+		{"10.25.0.0", "29", "10.25.0.0", "29", true},
+		{"10.25.0.0", "29", "10.25.0.8", "29", false},
+		{"10.25.0.0", "24", "10.25.0.8", "29", true},
+		{"10.25.0.8", "29", "10.25.0.0", "24", true},
+		{"10.25.0.0", "28", "10.25.0.16", "28", false},
+		{"2001:db8::", "64", "2001:db8::", "48", true},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing subnet overlap, %v", i)
+		t.Run(testname, func(t *testing.T) {
+			ans, err := subnetsOverlap(tt.network1, tt.mask_bits1, tt.network2, tt.mask_bits2)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if ans != tt.overlap {
+				t.Errorf("got %v, want %v", ans, tt.overlap)
+			}
+		})
+	}
+
+	if _, err := subnetsOverlap("not-an-ip", "24", "10.25.0.0", "24"); err == nil {
+		t.Errorf("expected error for invalid network")
+	}
+}
+
+func TestListSubtract(t *testing.T) {
+	var tests = []struct {
+		a, b, want string
+	}{
+		{"a,b,c", "b", "a,c"},
+		{"a, b", "a,b", ""},
+		{"", "a", ""},
+		{"a,,b", "", "a,b"},
+	}
+
+	for _, tt := range tests {
+		if got := listSubtract(tt.a, tt.b); got != tt.want {
+			t.Errorf("listSubtract(%q, %q) got %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFlattenTagList(t *testing.T) {
+	if got := flattenTagList([]interface{}{"a", "b"}); got != "a,b" {
+		t.Errorf("got %q, want a,b", got)
+	}
+	if got := flattenTagList(nil); got != "" {
+		t.Errorf("got %q, want empty", got)
+	}
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The generated libdevice42 params never write empty form values, so an
+// attribute removed from configuration can't be cleared through them. The
+// fields to clear are carried on the request context and added back by
+// clearingTransport.
+
+type clearedFormParamsKey struct{}
+
+// withClearedFormParams returns a context that makes the request send the
+// given form params as explicit empty values.
+func withClearedFormParams(ctx context.Context, fields ...string) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, clearedFormParamsKey{}, fields)
+}
+
+func clearedFormParams(ctx context.Context) []string {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(clearedFormParamsKey{}).([]string)
+	return fields
+}
+
+// changedToEmpty returns the attributes that changed to an empty string. The
+// attribute names double as the form param names.
+func changedToEmpty(d *schema.ResourceData, keys ...string) []string {
+	cleared := make([]string, 0)
+	for _, k := range keys {
+		if d.HasChange(k) && d.Get(k).(string) == "" {
+			cleared = append(cleared, k)
+		}
+	}
+	return cleared
+}
+
+type clearingTransport struct {
+	runtime.ClientTransport
+}
+
+func (t *clearingTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	if fields := clearedFormParams(op.Context); len(fields) > 0 {
+		op.Params = &clearingParams{ClientRequestWriter: op.Params, fields: fields}
+	}
+	return t.ClientTransport.Submit(op)
+}
+
+type clearingParams struct {
+	runtime.ClientRequestWriter
+	fields []string
+}
+
+func (p *clearingParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	if err := p.ClientRequestWriter.WriteToRequest(r, reg); err != nil {
+		return err
+	}
+	for _, f := range p.fields {
+		if err := r.SetFormParam(f, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timeoutTransport bounds every request by the provider `request_timeout`.
+// Requests carry the resource context, which makes the runtime drop its own
+// per-request timeout.
+type timeoutTransport struct {
+	runtime.ClientTransport
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	if t.timeout <= 0 {
+		return t.ClientTransport.Submit(op)
+	}
+
+	ctx := op.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	op.Context = ctx
+
+	return t.ClientTransport.Submit(op)
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	ipam "github.com/poroping/libdevice42/client/ip_a_m"
+)
+
+type testFormRequest struct {
+	runtime.ClientRequest
+	form url.Values
+}
+
+func (r *testFormRequest) SetFormParam(name string, values ...string) error {
+	r.form[name] = values
+	return nil
+}
+
+func (r *testFormRequest) SetQueryParam(name string, values ...string) error {
+	return nil
+}
+
+func (r *testFormRequest) SetTimeout(timeout time.Duration) error {
+	return nil
+}
+
+func TestClearingParams(t *testing.T) {
+	notes := "some notes"
+	params := ipam.NewPostIPAMIpsParams()
+	params.Notes = &notes
+	params.Label = new(string)
+
+	ctx := withClearedFormParams(context.Background(), "label", "tags")
+
+	if got := clearedFormParams(ctx); !reflect.DeepEqual(got, []string{"label", "tags"}) {
+		t.Fatalf("got %v, want [label tags]", got)
+	}
+
+	r := &testFormRequest{form: url.Values{}}
+	p := &clearingParams{ClientRequestWriter: params, fields: clearedFormParams(ctx)}
+
+	if err := p.WriteToRequest(r, strfmt.Default); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	want := url.Values{
+		"notes": {"some notes"},
+		"label": {""},
+		"tags":  {""},
+	}
+
+	if !reflect.DeepEqual(r.form, want) {
+		t.Errorf("got %v, want %v", r.form, want)
+	}
+
+	if got := clearedFormParams(withClearedFormParams(context.Background())); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+type testTransport struct {
+	op *runtime.ClientOperation
+}
+
+func (t *testTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	t.op = op
+	if _, ok := op.Context.Deadline(); !ok {
+		return nil, nil
+	}
+	return op.Context.Err(), nil
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	inner := &testTransport{}
+	tr := &timeoutTransport{ClientTransport: inner, timeout: time.Minute}
+
+	res, _ := tr.Submit(&runtime.ClientOperation{})
+
+	if _, ok := inner.op.Context.Deadline(); !ok {
+		t.Fatalf("expected request deadline")
+	}
+	if res != nil {
+		t.Errorf("expected live context during request, got %v", res)
+	}
+	if inner.op.Context.Err() == nil {
+		t.Errorf("expected context to be cancelled after the request")
+	}
+
+	tr.timeout = 0
+	tr.Submit(&runtime.ClientOperation{Context: context.Background()})
+
+	if _, ok := inner.op.Context.Deadline(); ok {
+		t.Errorf("expected no deadline without request_timeout")
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	vlanMin = 1
+	vlanMax = 4094
+)
+
+const (
+	vlanAllocationLowest     = "lowest"
+	vlanAllocationHighest    = "highest"
+	vlanAllocationRandom     = "random"
+	vlanAllocationRoundRobin = "round-robin"
+)
+
+var vlanAllocationStrategies = []string{
+	vlanAllocationLowest,
+	vlanAllocationHighest,
+	vlanAllocationRandom,
+	vlanAllocationRoundRobin,
+}
+
+// vlanAllocationRand is shared by parallel creates, a rand.Rand isn't safe for
+// concurrent use on its own.
+var (
+	vlanAllocationRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	vlanAllocationRandMu sync.Mutex
+)
+
+// parseVlanRange expands a VLAN range expression into a sorted list of VLAN
+// numbers. The expression is a comma separated list of single VLANs (`300`),
+// inclusive ranges (`100-199`) and exclusions of either form prefixed with `!`
+// (`!150`, `!160-169`). Exclusions are applied after all inclusions.
+func parseVlanRange(s string) ([]int, error) {
+	include := make(map[int]bool)
+	exclude := make(map[int]bool)
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+
+		if item == "" {
+			return nil, fmt.Errorf("empty entry in VLAN range %q", s)
+		}
+
+		target := include
+		if strings.HasPrefix(item, "!") {
+			target = exclude
+			item = strings.TrimSpace(strings.TrimPrefix(item, "!"))
+		}
+
+		min, max, err := parseVlanRangeItem(item)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := min; i <= max; i++ {
+			target[i] = true
+		}
+	}
+
+	vlans := make([]int, 0, len(include))
+	for i := range include {
+		if !exclude[i] {
+			vlans = append(vlans, i)
+		}
+	}
+
+	if len(vlans) == 0 {
+		return nil, fmt.Errorf("VLAN range %q does not contain any VLANs", s)
+	}
+
+	sort.Ints(vlans)
+
+	return vlans, nil
+}
+
+func parseVlanRangeItem(item string) (int, int, error) {
+	bounds := strings.Split(item, "-")
+
+	if len(bounds) > 2 {
+		return 0, 0, fmt.Errorf("invalid VLAN range %q, expected <min>-<max>", item)
+	}
+
+	min, err := parseVlanNumber(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(bounds) == 1 {
+		return min, min, nil
+	}
+
+	max, err := parseVlanNumber(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid VLAN range %q, %d is greater than %d", item, min, max)
+	}
+
+	return min, max, nil
+}
+
+func parseVlanNumber(s string) (int, error) {
+	s = strings.TrimSpace(s)
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid VLAN number %q", s)
+	}
+
+	if i < vlanMin || i > vlanMax {
+		return 0, fmt.Errorf("VLAN number %d out of range %d-%d", i, vlanMin, vlanMax)
+	}
+
+	return i, nil
+}
+
+// pickVlan chooses a VLAN from the sorted free list according to strategy.
+// Round-robin takes the first free VLAN above the highest used one and wraps
+// around to the lowest free VLAN, spreading allocations across the range.
+func pickVlan(free, used []int, strategy string) int {
+	switch strategy {
+	case vlanAllocationHighest:
+		return free[len(free)-1]
+	case vlanAllocationRandom:
+		vlanAllocationRandMu.Lock()
+		defer vlanAllocationRandMu.Unlock()
+		return free[vlanAllocationRand.Intn(len(free))]
+	case vlanAllocationRoundRobin:
+		last := 0
+		for _, u := range used {
+			if u > last {
+				last = u
+			}
+		}
+		for _, f := range free {
+			if f > last {
+				return f
+			}
+		}
+	}
+
+	return free[0]
+}
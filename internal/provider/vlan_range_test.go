@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestParseVlanRange(t *testing.T) {
+	var tests = []struct {
+		s     string
+		vlans []int
+		err   bool
+	}{
+		{"100", []int{100}, false},
+		{"100-103", []int{100, 101, 102, 103}, false},
+		{"100-102,300,400-401", []int{100, 101, 102, 300, 400, 401}, false},
+		{"100-102, 300 , 400-401", []int{100, 101, 102, 300, 400, 401}, false},
+		{"100-105,!102", []int{100, 101, 103, 104, 105}, false},
+		{"100-105,!101-104", []int{100, 105}, false},
+		{"!102,100-103", []int{100, 101, 103}, false},
+		{"100-101,101-102", []int{100, 101, 102}, false},
+		{"4094", []int{4094}, false},
+		{"1-2", []int{1, 2}, false},
+		{"", nil, true},
+		{"100,,200", nil, true},
+		{"0", nil, true},
+		{"4095", nil, true},
+		{"200-100", nil, true},
+		{"100-200-300", nil, true},
+		{"abc", nil, true},
+		{"100-", nil, true},
+		{"!100", nil, true},
+		{"100,!100", nil, true},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing VLAN range parsing, %v %q", i, tt.s)
+		t.Run(testname, func(t *testing.T) {
+			vlans, err := parseVlanRange(tt.s)
+			if tt.err {
+				if err == nil {
+					t.Errorf("expected error, got %v", vlans)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(vlans, tt.vlans) {
+				t.Errorf("got %v, want %v", vlans, tt.vlans)
+			}
+		})
+	}
+}
+
+func TestPickVlan(t *testing.T) {
+	var tests = []struct {
+		free, used []int
+		strategy   string
+		vlan       int
+	}{
+		{[]int{101, 103, 104}, []int{100, 102}, vlanAllocationLowest, 101},
+		{[]int{101, 103, 104}, []int{100, 102}, vlanAllocationHighest, 104},
+		{[]int{101, 103, 104}, []int{100, 102}, vlanAllocationRoundRobin, 103},
+		{[]int{101, 103, 104}, []int{105}, vlanAllocationRoundRobin, 101},
+		{[]int{101, 103, 104}, []int{}, vlanAllocationRoundRobin, 101},
+		{[]int{101}, []int{100}, vlanAllocationRandom, 101},
+	}
+
+	for i, tt := range tests {
+		testname := fmt.Sprintf("Testing VLAN allocation, %v %s", i, tt.strategy)
+		t.Run(testname, func(t *testing.T) {
+			if ans := pickVlan(tt.free, tt.used, tt.strategy); ans != tt.vlan {
+				t.Errorf("got %v, want %v", ans, tt.vlan)
+			}
+		})
+	}
+
+	free := []int{1, 2, 3}
+	for i := 0; i < 20; i++ {
+		ans := pickVlan(free, nil, vlanAllocationRandom)
+		if ans < 1 || ans > 3 {
+			t.Errorf("random pick %v not in %v", ans, free)
+		}
+	}
+
+	// parallel creates pick concurrently, run with -race.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pickVlan(free, nil, vlanAllocationRandom)
+		}()
+	}
+	wg.Wait()
+}